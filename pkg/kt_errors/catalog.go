@@ -0,0 +1,67 @@
+package kt_errors
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Parses a catalog of named error definitions into `FaultTemplate`s keyed by their id. The document can be YAML or JSON (JSON is valid YAML too) - e.g.
+//
+//	quota_exceeded:
+//	  kind: illegal_state
+//	  public: true
+//	  message: "quota of tenant {tenantId} is exceeded"
+//	  messagesByAudience:
+//	    user: "you have used up your quota"
+//	  errorCodes: [quota_exceeded]
+//	  isRetryable: false
+//
+// This way the catalog can be maintained outside of the code (even by non-Go teams). Use `NewFaultFromCatalog()` to create Faults from it.
+//
+// Returns a non-public `ValidationFault` if the document can not be parsed (`VALIDATION_ERRCODE_WRONG_FORMAT` error code - with the parser error as
+// cause) or an entry has unknown kind (`VALIDATION_ERRCODE_INVALID_VALUE` error code - see `RegisterFaultKinds()`). An empty document is an empty catalog.
+func LoadFaultCatalog(r io.Reader) (map[string]FaultTemplate, error) {
+	catalog := make(map[string]FaultTemplate)
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&catalog); err != nil && !errors.Is(err, io.EOF) {
+		return nil, NewFaultBuilder(ValidationFault).
+			WithMessageTemplate("Fault catalog can not be parsed").
+			WithErrorCode(VALIDATION_ERRCODE_WRONG_FORMAT).
+			WithCause(err).
+			Build()
+	}
+	for _, id := range slices.Sorted(maps.Keys(catalog)) {
+		if kind := catalog[id].Kind; !IsRegisteredFaultKind(kind) {
+			return nil, NewFaultBuilder(ValidationFault).
+				WithMessageTemplate("Fault catalog entry '{catalogId}' has unknown kind '{kind}' - see `RegisterFaultKinds()`").
+				WithErrorCode(VALIDATION_ERRCODE_INVALID_VALUE).
+				WithLabel("catalogId", id).
+				WithLabel("kind", kind).
+				Build()
+		}
+	}
+	return catalog, nil
+}
+
+// Creates a Fault from the template registered under `id` in the catalog (see `LoadFaultCatalog()`) - attaching the given runtime labels.
+//
+// If there is no such id in the catalog then a non-public `IllegalStateFault` with `ILLEGALSTATE_ERRCODE_CODE_BUG` error code is returned instead (with
+// the id as label "catalogId") - as this is clearly a mistake in the code or in the catalog.
+func NewFaultFromCatalog(catalog map[string]FaultTemplate, id string, labels map[string]any) Fault {
+	template, found := catalog[id]
+	if !found {
+		return NewFaultBuilder(IllegalStateFault).
+			WithMessageTemplate("Unknown fault catalog id '{catalogId}'").
+			WithErrorCode(ILLEGALSTATE_ERRCODE_CODE_BUG).
+			WithLabel("catalogId", id).
+			Build()
+	}
+	return NewFaultBuilderFromTemplate(template).
+		WithLabels(labels).
+		Build()
+}
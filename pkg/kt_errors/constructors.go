@@ -0,0 +1,210 @@
+package kt_errors
+
+// Creates a `ValidationFault` from the result of multiple field validations in one go.
+//
+// The `fieldErrors` map is field -> reason where reason is expected to be an error code (see predefined `VALIDATION_ERRCODE_*` constants but you can also
+// use your own ones). Each reason is added to the Fault as error code (if reason is empty then `VALIDATION_ERRCODE_INVALID_VALUE` is used). The map itself is
+// attached as label "fields", and the number of failed fields as label "fieldCount" - which is used in the summary message too.
+//
+// If `fieldErrors` is empty then Nil is returned.
+func NewMultiFieldValidationFault(fieldErrors map[string]string, public bool) Fault {
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	var builder *FaultBuilder
+	if public {
+		builder = NewPublicFaultBuilder(ValidationFault)
+	} else {
+		builder = NewFaultBuilder(ValidationFault)
+	}
+	fields := make(map[string]string, len(fieldErrors))
+	for field, reason := range fieldErrors {
+		if reason == "" {
+			reason = VALIDATION_ERRCODE_INVALID_VALUE
+		}
+		fields[field] = reason
+		builder.WithErrorCodes(reason)
+	}
+	return builder.
+		WithMessageTemplate("Validation failed on {fieldCount} field(s)").
+		WithLabel("fieldCount", len(fields)).
+		WithLabel("fields", fields).
+		Build()
+}
+
+// Creates a public `ValidationFault` telling the given mandatory field is missing - with `VALIDATION_ERRCODE_MISSING_MANDATORY` error code. The field
+// is attached as label "field".
+func NewMissingFieldFault(field string) Fault {
+	return NewPublicFaultBuilder(ValidationFault).
+		WithMessageTemplate("Mandatory field '{field}' is missing").
+		WithErrorCode(VALIDATION_ERRCODE_MISSING_MANDATORY).
+		WithLabel("field", field).
+		Build()
+}
+
+// Creates a public `ValidationFault` telling the given field has an invalid value - with `VALIDATION_ERRCODE_INVALID_VALUE` error code. The field and
+// the value are attached as labels "field" and "value".
+//
+// Please note: the value appears in the message - so do not use this with sensitive values!
+func NewInvalidValueFault(field string, value any) Fault {
+	return NewPublicFaultBuilder(ValidationFault).
+		WithMessageTemplate("Field '{field}' has invalid value '{value}'").
+		WithErrorCode(VALIDATION_ERRCODE_INVALID_VALUE).
+		WithLabel("field", field).
+		WithLabel("value", value).
+		Build()
+}
+
+// Creates a public `ValidationFault` telling the given field has wrong data type - with `VALIDATION_ERRCODE_WRONG_DATATYPE` error code. The field, the
+// expected and the received type are attached as labels "field", "expected" and "got".
+func NewWrongTypeFault(field, expected, got string) Fault {
+	return NewPublicFaultBuilder(ValidationFault).
+		WithMessageTemplate("Field '{field}' has wrong type - expected {expected} but got {got}").
+		WithErrorCode(VALIDATION_ERRCODE_WRONG_DATATYPE).
+		WithLabel("field", field).
+		WithLabel("expected", expected).
+		WithLabel("got", got).
+		Build()
+}
+
+// Creates a public `ResourceNotFoundFault` telling the given resource does not exist - with `CONSTRAINTVIOLATION_ERRCODE_DOES_NOT_EXIST` error code.
+// The resource type and the id are attached as labels "resourceType" and "id". Maps to HTTP 404.
+//
+// See `NewNotFoundFaultNonPublic()` for the non-public variant.
+func NewNotFoundFault(resourceType, id string) Fault {
+	return newNotFoundFault(resourceType, id, true)
+}
+
+// The non-public variant of `NewNotFoundFault()`.
+func NewNotFoundFaultNonPublic(resourceType, id string) Fault {
+	return newNotFoundFault(resourceType, id, false)
+}
+
+func newNotFoundFault(resourceType, id string, public bool) Fault {
+	return NewFaultBuilder(ResourceNotFoundFault).
+		WithPublic(public).
+		WithMessageTemplate("{resourceType} '{id}' does not exist").
+		WithErrorCode(CONSTRAINTVIOLATION_ERRCODE_DOES_NOT_EXIST).
+		WithLabel("resourceType", resourceType).
+		WithLabel("id", id).
+		Build()
+}
+
+// Creates a public `ConstraintViolationFault` telling the given resource already exists - with `CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST` error code.
+// The resource type and the id are attached as labels "resourceType" and "id". Maps to HTTP 409.
+//
+// See `NewAlreadyExistsFaultNonPublic()` for the non-public variant.
+func NewAlreadyExistsFault(resourceType, id string) Fault {
+	return newAlreadyExistsFault(resourceType, id, true)
+}
+
+// The non-public variant of `NewAlreadyExistsFault()`.
+func NewAlreadyExistsFaultNonPublic(resourceType, id string) Fault {
+	return newAlreadyExistsFault(resourceType, id, false)
+}
+
+func newAlreadyExistsFault(resourceType, id string, public bool) Fault {
+	return NewFaultBuilder(ConstraintViolationFault).
+		WithPublic(public).
+		WithMessageTemplate("{resourceType} '{id}' already exists").
+		WithErrorCode(CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST).
+		WithLabel("resourceType", resourceType).
+		WithLabel("id", id).
+		Build()
+}
+
+// Creates a public `ConstraintViolationFault` telling the assumed version of a resource does not match the actual one - with
+// `CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT` error code. The versions are attached as labels "assumedVersion" and "actualVersion". Maps to HTTP 409.
+//
+// See `NewVersionConflictFaultNonPublic()` for the non-public variant.
+func NewVersionConflictFault(assumed, actual any) Fault {
+	return newVersionConflictFault(assumed, actual, true)
+}
+
+// The non-public variant of `NewVersionConflictFault()`.
+func NewVersionConflictFaultNonPublic(assumed, actual any) Fault {
+	return newVersionConflictFault(assumed, actual, false)
+}
+
+func newVersionConflictFault(assumed, actual any, public bool) Fault {
+	return NewFaultBuilder(ConstraintViolationFault).
+		WithPublic(public).
+		WithMessageTemplate("Version conflict - assumed version {assumedVersion} but actual version is {actualVersion}").
+		WithErrorCode(CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT).
+		WithLabel("assumedVersion", assumed).
+		WithLabel("actualVersion", actual).
+		Build()
+}
+
+// Creates a public `AuthorizationFault` telling the actor has no permission to do the given action - with `AUTHORIZATION_NO_PERMISSION` error code.
+// The action is attached as label "action". Maps to HTTP 403.
+//
+// See `NewNoPermissionFaultNonPublic()` for the non-public variant.
+func NewNoPermissionFault(action string) Fault {
+	return newNoPermissionFault(action, true)
+}
+
+// The non-public variant of `NewNoPermissionFault()`.
+func NewNoPermissionFaultNonPublic(action string) Fault {
+	return newNoPermissionFault(action, false)
+}
+
+func newNoPermissionFault(action string, public bool) Fault {
+	return NewFaultBuilder(AuthorizationFault).
+		WithPublic(public).
+		WithMessageTemplate("No permission to {action}").
+		WithErrorCode(AUTHORIZATION_NO_PERMISSION).
+		WithLabel("action", action).
+		Build()
+}
+
+// Creates a public Fault from an HTTP status code - useful when you proxy the error of another HTTP service and want to synthesize a reasonable Fault
+// from its response. The status code is attached as label "http.status" and the given message becomes the message template.
+//
+// The mapping is:
+//   - 400 - `ValidationFault`
+//   - 401 - `AuthenticationFault`
+//   - 403 - `AuthorizationFault`
+//   - 404 - `ResourceNotFoundFault`
+//   - 409 - `ConstraintViolationFault` with `CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST` error code
+//   - 412 - `ConstraintViolationFault` with `CONSTRAINTVIOLATION_ERRCODE_PRECONDITION_FAILED` error code
+//   - 429 - `IllegalStateFault` with `ILLEGALSTATE_ERRCODE_RATE_LIMITED` error code
+//   - 501 - `NotImplementedFault`
+//   - 503 - `IllegalStateFault` with `ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE` error code
+//   - anything else - `RuntimeFault`
+//
+// The Fault is retryable for 429 and 503 - not retryable otherwise.
+func NewFaultFromHttpStatus(status int, message string) Fault {
+	kind := RuntimeFault
+	var errCode string
+	switch status {
+	case 400:
+		kind = ValidationFault
+	case 401:
+		kind = AuthenticationFault
+	case 403:
+		kind = AuthorizationFault
+	case 404:
+		kind = ResourceNotFoundFault
+	case 409:
+		kind = ConstraintViolationFault
+		errCode = CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST
+	case 412:
+		kind = ConstraintViolationFault
+		errCode = CONSTRAINTVIOLATION_ERRCODE_PRECONDITION_FAILED
+	case 429:
+		kind = IllegalStateFault
+		errCode = ILLEGALSTATE_ERRCODE_RATE_LIMITED
+	case 501:
+		kind = NotImplementedFault
+	case 503:
+		kind = IllegalStateFault
+		errCode = ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE
+	}
+	return NewPublicFaultBuilder(kind).
+		WithMessageTemplate(message).
+		WithErrorCode(errCode).
+		WithIsRetryable(status == 429 || status == 503).
+		WithLabel(httpStatusLabel, status).
+		Build()
+}
@@ -1,15 +1,26 @@
 package kt_errors
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"maps"
+	"reflect"
+	"runtime"
 	"slices"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
+	"github.com/keytiles/lib-logging-golang/v2/pkg/kt_logging"
 	"github.com/keytiles/lib-sets-golang/ktsets"
 	"github.com/keytiles/lib-utils-golang/pkg/kt_utils"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type FaultKind = string
@@ -54,8 +65,12 @@ const (
 	ILLEGALSTATE_ERRCODE_EXCPECTATION_FAILED = "expectation_failed"
 	// Something timed out - job is not done, state is not good
 	ILLEGALSTATE_ERRCODE_TIMED_OUT = "timed_out"
+	// The operation was cancelled (e.g. the context was cancelled) before the job could have been done
+	ILLEGALSTATE_ERRCODE_CANCELLED = "cancelled"
 	// Something has reached its limits - no more is possible
 	ILLEGALSTATE_ERRCODE_EXHAUSTED = "exhausted"
+	// The caller sent too many requests and was rate limited - retrying later is expected to succeed (see also `WithRetryAfter()` builder method)
+	ILLEGALSTATE_ERRCODE_RATE_LIMITED = "rate_limited"
 	// We tried to serialize something into JSON/Yaml/binary etc but it failed. This often can indicate a problem with the original input.
 	ILLEGALSTATE_ERRCODE_SERIALIZATION_FAILED = "serialization_failed"
 	// We tried to deserialize something from JSON/Yaml/binary etc but it failed. This often can indicate a problem with the original input.
@@ -106,11 +121,55 @@ const (
 	AUTHORIZATION_ERRCODE_FAILED = "authorization_failed"
 )
 
+const (
+	// The CloudEvents "type" of a Fault is this prefix + the kind of the Fault
+	cloudEventTypePrefix = "com.keytiles.fault."
+	// The key in `GetLabelsDeep()` under which conflicting labels are recorded
+	labelConflictsKey = "__conflicts"
+	// The label we store the latency of the failed operation in - see `WithLatency()` builder method
+	latencyLabel = "latencyMs"
+	// The label we store the retry-after hint in - see `WithRetryAfter()` builder method
+	retryAfterLabel = "retryAfterMs"
+	// The retry-after hint `WithTransientDependency()` builder method sets if there is none yet
+	defaultTransientRetryAfter = 5 * time.Second
+
+	// The labels `WithHttpRequestContext()` and `WithHttpResponseContext()` builder methods are using
+	httpMethodLabel          = "http.method"
+	httpUrlLabel             = "http.url"
+	httpRequestHeadersLabel  = "http.requestHeaders"
+	httpStatusLabel          = "http.status"
+	httpResponseHeadersLabel = "http.responseHeaders"
+	httpResponseBodyLabel    = "http.responseBody"
+	// Sensitive header values are replaced with this
+	redactedValue = "[REDACTED]"
+	// The response body snippet is truncated to this many characters (runes)
+	httpBodySnippetMaxLength = 512
+)
+
+// Headers which values are never attached to a Fault as they can carry credentials - see `WithHttpRequestContext()`
+var sensitiveHttpHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Url query parameters which values are never attached to a Fault as they can carry credentials - see `WithHttpRequestContext()`
+var sensitiveHttpQueryParams = []string{
+	"access_token", "refresh_token", "id_token", "token", "api_key", "apikey", "key", "password", "secret", "client_secret", "signature", "sig",
+}
+
 const (
 	// Audience role - user - for audience facing message templates.
 	MSGAUDIENCE_USER = "user"
 )
 
+const (
+	// Kind category - the problem is caused by the client (typically 4xx HTTP status codes) - see `KindCategory()`.
+	KINDCATEGORY_CLIENT = "client"
+	// Kind category - the problem is on the server side (typically 5xx HTTP status codes) - see `KindCategory()`.
+	KINDCATEGORY_SERVER = "server"
+)
+
+// The version of the format `ToFullJSON()` produces - it is serialized as "schemaVersion" field. It is increased whenever the format changes in an
+// incompatible way, so consumers of serialized Faults can handle the format evolution across service versions.
+const FULLJSON_SCHEMA_VERSION = "1"
+
 // Basically true/false options to change the serialization behavior
 type SerializationOption int
 
@@ -127,8 +186,163 @@ const (
 	// By default the serialization only happens if Fault is public - to prevent data leak non-public Faults simply returning blank form.
 	// But if you set this option explicitly then this defense mechanism gets disabled.
 	AllowNonPublicSerialization = 4
+	// By default error codes are serialized as flat strings. If you set this option then they are serialized in the structured form (category + code) - see
+	// `ErrorCode` type.
+	StructuredErrorCodes = 5
+	// Only has effect in the natural form (see `ToNaturalJSON()`) and only combined with `AllowNonPublicSerialization` - this is meant for internal sinks
+	// (like an error tracking service) only! If set then the cause of the Fault is also serialized as "cause" - Fault causes recursively in the natural form,
+	// other errors as their error string. If the Fault has multiple causes then the first one is serialized.
+	IncludeCause = 6
+)
+
+// Values from here are reserved for the `OnlyAudiences()` options - far away from the plain options so they can not be hit accidentally
+const onlyAudiencesOptionBase SerializationOption = 1 << 30
+
+// The max number of distinct audience combinations `OnlyAudiences()` interns - see there
+const maxOnlyAudiencesOptions = 1024
+
+// Returned by `OnlyAudiences()` once `maxOnlyAudiencesOptions` is reached - filters out all audiences
+const onlyAudiencesOverflowOption SerializationOption = onlyAudiencesOptionBase - 1
+
+// As `SerializationOption` is a simple int the audience sets of `OnlyAudiences()` options are interned here - the option value points into this
+var (
+	onlyAudiencesLock    sync.RWMutex
+	onlyAudiencesSets    []ktsets.Set[string]
+	onlyAudiencesOptions = make(map[string]SerializationOption)
+)
+
+// Only has effect in the full form (see `ToFullJSON()`) - the "messagesByAudience" is filtered to the given audiences, the rest is left out. Useful if you
+// respond to a specific client and e.g. want to expose the "user" audience message but hide the "operator" one. If the option is passed multiple times
+// then the audiences are combined.
+//
+// Please note: with `ResolveMessages` the {var} variables of the left out audience messages are still removed from the labels (unless you also pass
+// `LeaveMessageVarsInLabels`) - so these labels are not leaking either.
+//
+// As `SerializationOption` is a simple int each distinct audience combination is interned in a package level registry (the same combination always gives
+// the same option). This registry is bounded to 1024 combinations - which is plenty for the intended use: a handful of fixed combinations (ideally kept in
+// package level vars). Never build the audience list from request data! Once the limit is reached a warning is logged and the returned option filters out
+// all audiences - so nothing leaks.
+func OnlyAudiences(audiences ...string) SerializationOption {
+	sorted := slices.Sorted(slices.Values(audiences))
+	key := strings.Join(sorted, "\x00")
+
+	onlyAudiencesLock.Lock()
+	option, found := onlyAudiencesOptions[key]
+	if !found && len(onlyAudiencesSets) < maxOnlyAudiencesOptions {
+		option = onlyAudiencesOptionBase + SerializationOption(len(onlyAudiencesSets))
+		onlyAudiencesSets = append(onlyAudiencesSets, ktsets.NewSet(sorted...))
+		onlyAudiencesOptions[key] = option
+		found = true
+	}
+	onlyAudiencesLock.Unlock()
+
+	if !found {
+		getDefaultLogger().Warn("OnlyAudiences() registry is full (%d distinct audience combinations) - option for audiences %v filters out all audiences", maxOnlyAudiencesOptions, sorted)
+		return onlyAudiencesOverflowOption
+	}
+	return option
+}
+
+// Returns the union of the audiences of all `OnlyAudiences()` options among the given options - and false if there was no such option.
+func onlyAudiencesOf(options []SerializationOption) (audiences ktsets.Set[string], found bool) {
+	onlyAudiencesLock.RLock()
+	defer onlyAudiencesLock.RUnlock()
+	audiences = ktsets.NewSet[string]()
+	for _, option := range options {
+		if option == onlyAudiencesOverflowOption {
+			found = true
+			continue
+		}
+		idx := int(option - onlyAudiencesOptionBase)
+		if idx >= 0 && idx < len(onlyAudiencesSets) {
+			audiences.Union(onlyAudiencesSets[idx])
+			found = true
+		}
+	}
+	return audiences, found
+}
+
+// Optional structured form of an error code - grouping the codes into categories so e.g. dashboards can roll up. The `Code` part is what the flat string
+// based API (`HasErrorCode()`, `GetErrorCodes()` etc) works with - flat codes simply have empty `Category`.
+type ErrorCode struct {
+	Category string `json:"category,omitempty" yaml:"category,omitempty"`
+	Code     string `json:"code" yaml:"code"`
+}
+
+// Returns the "category/code" form - or just the code if there is no category.
+func (ec ErrorCode) String() string {
+	if ec.Category == "" {
+		return ec.Code
+	}
+	return ec.Category + "/" + ec.Code
+}
+
+// The public-safe view of a Fault - see `fault.PublicView()`. It is a simple value (a snapshot), changing it does not affect the Fault.
+type FaultView struct {
+	Kind       FaultKind `json:"kind" yaml:"kind"`
+	Message    string    `json:"message" yaml:"message"`
+	ErrorCodes []string  `json:"errorCodes" yaml:"errorCodes"`
+	Retryable  bool      `json:"isRetryable" yaml:"isRetryable"`
+	HttpStatus int       `json:"httpStatus" yaml:"httpStatus"`
+}
+
+// Typed key of a `context.Context` value - see `FaultBuilder.WithContext()` builder method. Using a dedicated type avoids collisions with context keys
+// of other packages. The key itself is used as the label name.
+type ContextKey string
+
+// Tells how to resolve conflicting keys when labels are merged - see `FaultBuilder.WithLabelsMerge()` builder method.
+type MergeStrategy int
+
+const (
+	// The incoming value replaces the already existing one. This is the default (the zero value) - and also how `WithLabels()` behaves.
+	Overwrite MergeStrategy = iota
+	// The already existing value wins, the incoming value of a conflicting key is ignored. Useful if a wrapper layer wants to add its context labels
+	// without clobbering the ones the inner layer already set.
+	KeepExisting
 )
 
+// Tells how serious a Fault is - see `FaultBuilder.WithSeverity()` builder method. Most Faults are fatal (the operation failed) but some of them are just
+// warnings or informational notices which can travel alongside a successful result (e.g. deprecation notices) - see `ToWarningJSON()`.
+type Severity int
+
+const (
+	// The operation failed. This is the default (the zero value).
+	SeverityError Severity = iota
+	// Non-fatal - the operation succeeded but something is worth attention (e.g. a deprecated parameter was used).
+	SeverityWarning
+	// Non-fatal - just an informational notice.
+	SeverityInfo
+)
+
+var severityNames = map[Severity]string{
+	SeverityError:   "error",
+	SeverityWarning: "warning",
+	SeverityInfo:    "info",
+}
+
+func (s Severity) String() string {
+	if name, found := severityNames[s]; found {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Implementation of the `encoding.TextMarshaler` iface - so the severity is serialized by its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Implementation of the `encoding.TextUnmarshaler` iface - the counterpart of `MarshalText()`.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range severityNames {
+		if name == string(text) {
+			*s = severity
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity '%s'", text)
+}
+
 // Our unified, data rich Keytiles-internal error which is able to carry many and all necessarry information and let it bubble up from literally any layers:
 // even from libraries or simply service internal layers.
 //
@@ -167,32 +381,150 @@ type Fault interface {
 	// Returns the message template unresolved (so with possible variable placeholders in it as is)
 	GetMessageTemplate() string
 	// Returns the message - with resolved variable placeholders from labels.
+	//
+	// Please note: the message of a built Fault is resolved once and memoized - it is a snapshot. The Fault's own methods changing the message template
+	// or the labels keep it up to date, but if a label value is a pointer or otherwise mutable value which you change later then the message still shows
+	// the value from the time of resolving.
 	GetMessage() string
+	// Appends the message (the very same as `GetMessage()` returns) to the given byte slice and returns the extended slice - just like the `strconv.AppendXXX()`
+	// functions. This enables zero-allocation patterns on hot logging paths writing into reused buffers.
+	AppendMessage(dst []byte) []byte
 	// Returns the message template meant for the given audience unresolved (so with possible variable placeholders in it as is).
 	// If there is no template for the requested audience, empty string is returned.
 	GetMessageTemplateForAudience(forAudience string) string
 	// Returns the message meant for the given audience - with resolved variable placeholders from labels.
 	// If there is no template for the requested audience, empty string is returned.
 	GetMessageForAudience(forAudience string) string
+	// Same as `GetMessageForAudience()` but if there is no template for the requested audience then it falls back to the default message (`GetMessage()`).
+	// Handy in rendering code which always wants something to show.
+	GetMessageForAudienceOrDefault(forAudience string) string
+	// Like `GetMessageForAudienceOrDefault()` but with a preference list of audiences - e.g. `GetMessagePreferred("user", "public")`. Returns the first
+	// non-empty resolved audience message following the given order and falls back to the default message (`GetMessage()`) if none of them has one.
+	// If no audience is given it behaves just like `GetMessage()`.
+	GetMessagePreferred(audiences ...string) string
+	// Like `GetMessage()` but the variable placeholders are resolved from the labels merged with the given extra labels (the extra ones win on key
+	// collision). This is a one-shot resolution - the Fault itself remains unchanged. Useful to inject per-request values at the boundary which are not
+	// stored in the Fault.
+	GetMessageWith(extraLabels map[string]any) string
+	// Same as `GetMessageWith()` but for the message meant for the given audience. If there is no template for the requested audience, empty string
+	// is returned.
+	GetMessageForAudienceWith(forAudience string, extraLabels map[string]any) string
+	// Returns the distinct placeholder variable names (sorted) used across the default and all audience message templates - useful for lint tools and
+	// for tests verifying every label is used. See also `TemplateVariableCounts()`.
+	TemplateVariables() []string
+	// Same as `TemplateVariables()` but tells how many times each placeholder variable occurs across the default and all audience message templates.
+	TemplateVariableCounts() map[string]int
 	// Returns map view of message templates by audiences.
 	// **Note:** This always makes and returns a copy so use it accordingly!
 	GetMessageTemplatesByAudience() map[string]string
+	// Returns the audiences this error has a message template for - sorted. Cheaper than `GetMessageTemplatesByAudience()` if you only need the names.
+	AudienceNames() []string
+	// Tells if this error has a message template for the given audience.
+	HasAudience(name string) bool
+	// Returns the short, human-quotable reference of the error (like "ERR-7F3A9C") - public errors get one automatically at build time. Users can quote this
+	// to support and support can map it to the internal logs. See also `SetReferenceGenerator()`.
+	// Non-public errors do not get a reference by default (unless explicitly set with builder method `WithReference()`) - so empty string is returned then.
+	GetReference() string
+	// Returns the documentation / troubleshooting URL attached with builder method `WithHelpUrl()` - or empty string if there is none. For public errors
+	// it is part of the natural and full JSON forms as "helpUrl" - so clients get a self-service troubleshooting link.
+	GetHelpUrl() string
+	// Returns the unique id (a random UUID) of this error instance - every built Fault gets its own one. Unlike the fingerprint-like grouping of similar
+	// errors, this identifies one single occurrence - useful for tracing. It is part of the full JSON form and the `String()` form. See also
+	// `SetInstanceIdGenerator()`.
+	GetInstanceId() string
+	// Returns a short, stable fingerprint of this error (like "9F3A2C1B") - derived from the kind, the message template and the error codes only. So
+	// occurrences of the same error (e.g. with different label values) share the fingerprint - useful to group them, or as a short code users can quote
+	// to support (see `OptionAttachFingerprint()`).
+	GetFingerprint() string
 	// Tells if this error is suitable to leave the private boundary or not (public = no implementation details leaking for sure).
 	IsPublic() bool
 	// We extend the error with the possibility of check if error is retryable.
 	IsRetryable() bool
+	// Explains why this Fault is or is not retryable - e.g. "kind validation is inherently non-retryable" or "auth method not supported" if retryability
+	// was requested (e.g. with builder method `WithIsRetryable()`) but the rules denied it. This way the retryability policy applied in `Build()` is not
+	// hidden. If retryability was requested but denied then `String()` shows the reason too.
+	RetryabilityReason() string
+	// Same as `IsRetryable()` but this one also walks the cause chain (see `WalkErrorChain()`) - and returns true if any Fault in the chain (starting with
+	// the Fault itself) is retryable. So a retryable cause wins over a non-retryable wrapper - useful for retry decisions where the real transient signal
+	// is buried deeper. Cycles in the chain are handled.
+	IsRetryableInChain() bool
+	// Returns how serious this Fault is - `SeverityError` unless set otherwise with builder method `WithSeverity()`.
+	GetSeverity() Severity
+	// Tells if this Fault is just a warning or an informational notice (see `GetSeverity()`) - so it can travel alongside a successful result. For these
+	// `GetHttpStatusCode()` returns 200.
+	IsNonFatal() bool
+	// Tells if the kind of this error is caused by the client (4xx style) - see utility function `KindCategory()` for details.
+	IsClientError() bool
+	// Tells if the kind of this error is a server side problem (5xx style) - see utility function `KindCategory()` for details.
+	IsServerError() bool
 	// Returns all associated error codes.
 	// **Note:** This always makes and returns a copy so use it accordingly! If possible use `HasErrorCode()` instead.
 	GetErrorCodes() []string
+	// Returns the error codes as one compact string - sorted and comma-joined like "['code1','code2']" (or "[]" if there are none). This is the form
+	// `Error()` and `String()` are using too - handy for log lines.
+	GetErrorCodesString() string
 	// Tells if this error is carrying ANY of the listed error codes or not.
 	HasErrorCode(codes ...string) bool
-	// Returns the Cause of this error - which is another (any) error.
+	// Tells if this error is carrying ALL of the listed error codes (it may carry others too) - case-sensitive and order-independent. Useful for policy checks
+	// requiring a specific combination of codes. With no codes listed it is true.
+	HasAllErrorCodes(codes ...string) bool
+	// Tells if this error is carrying EXACTLY the listed error codes - no more, no less. Case-sensitive and order-independent, duplicates in the list do
+	// not matter.
+	HasExactErrorCodes(codes ...string) bool
+	// Returns all associated error codes in structured form (see `ErrorCode`). Flat error codes have empty `Category`.
+	// **Note:** This always makes and returns a new slice so use it accordingly!
+	GetStructuredErrorCodes() []ErrorCode
+	// Returns all the tags of this error (sorted) - see builder method `WithTags()`.
+	// **Note:** This always makes and returns a copy so use it accordingly! If possible use `HasTag()` instead.
+	GetTags() []string
+	// Tells if this error is carrying the given tag or not.
+	HasTag(tag string) bool
+	// Returns the Cause of this error - which is another (any) error. If the error has multiple causes (see builder method `WithCauses()`) then the first one
+	// is returned.
 	GetCause() error
+	// Same as `GetCause()` but returns the cause as a Fault - so you do not need the `IsFault()` check. Returns false (and nil) if there is no cause or the
+	// cause is not a Fault.
+	GetCauseFault() (Fault, bool)
+	// Returns the Faults of the cause chain (see `WalkCauses()`) in walk order (depth first, all causes) - the Fault itself is not included. Other errors
+	// in the chain are skipped (but the walk goes on through them). If there are no Faults in the chain you get back an empty slice.
+	GetFaultCauseChain() []Fault
+	// Returns a quick summary of the structure of the error chain (walked just like in `WalkErrorChain()` - all branches, cycles are detected) starting
+	// with this Fault:
+	//   - `depth` - the number of links on the longest path of the chain (this Fault included)
+	//   - `faultCount` - how many of the links are Faults (this Fault included)
+	//   - `hasNonPublic` - if any of the links is non-public (other errors are never public)
+	//
+	// This helps boundary handlers e.g. to decide the logging verbosity or to detect deeply nested wrapping. For a nil Fault (and `NoFault`) you get zeros.
+	ChainSummary() (depth int, faultCount int, hasNonPublic bool)
+	// Returns all the Causes of this error - some failures genuinely have several independent root causes (e.g. two downstreams both failed).
+	// **Note:** This always makes and returns a copy so use it accordingly!
+	GetCauses() []error
+	// Walks the cause chain starting with this Fault itself - invoking the callback on each error in the chain. If the callback returns false the walk stops
+	// early - so it is easy to e.g. find the first error in the chain matching a predicate. Cycles in the chain are detected and the walk stops then too.
+	// Note: this is a wrapper around the utility function `WalkErrorChain()` - see the details there.
+	WalkCauses(fn func(err error) bool)
 	// Errors can carry a set of labels. This returns them all.
 	// **Note:** This always makes and returns a copy so use it accordingly! If you can use `GetLabel()` method instead.
 	GetLabels() map[string]any
+	// Returns all labels across the cause chain - merged. The labels of this Fault come first then the labels of the Faults in the cause chain - the outer
+	// one wins. Identical key-value pairs are simply de-duplicated, but if the same key comes with different values then this conflict is recorded under
+	// the "__conflicts" key (a map of key -> list of all the different values, outer first) - for debugging purposes.
+	// **Note:** This always makes and returns a new map so use it accordingly!
+	GetLabelsDeep() map[string]any
 	// Returns a specific label if Fault has it - or Nil if does not have it. You can also take and use the returned `found` flag.
 	GetLabel(key string) (value any, found bool)
+	// Returns the latency of the operation which failed - if it was attached with the builder `WithLatency()` method. You can also take and use the returned
+	// `found` flag. The latency is carried in label "latencyMs" (in milliseconds).
+	GetLatency() (latency time.Duration, found bool)
+	// Returns the hint how long the caller should wait before retrying - if it was attached with the builder `WithRetryAfter()` method. You can also take
+	// and use the returned `found` flag. The hint is carried in label "retryAfterMs" (in milliseconds).
+	GetRetryAfter() (retryAfter time.Duration, found bool)
+	// Returns a label as `time.Time` - if the Fault has it and it is a time. As in the JSON forms `time.Time` labels are rendered as RFC3339 strings, a
+	// string value is parsed back (e.g. after a JSON roundtrip). You can also take and use the returned `found` flag.
+	GetTimeLabel(key string) (value time.Time, found bool)
+	// Returns the keys of those labels which were explicitly marked as safe to be published - see builder method `WithPublicLabels()`.
+	// **Note:** This always makes and returns a copy so use it accordingly!
+	GetPublicLabelKeys() []string
 	// Error supports tracking the call chain. You can optionally use this (or not, up to you). But if you do, this method returns the content of this.
 	// The `GetSource()` method returns where the error was born - you can set this with the builder `WithSource()` method. Then as the error bubbles
 	// up, each hop can use the `AddCallerToCallStack()` method. This is how call stack is building up - what you can retrieve with this method.
@@ -219,14 +551,31 @@ type Fault interface {
 	// It is really a prefix - imagine a simple concatenation! So you need to include separators, white-spaces etc at the end of your prefix str!
 	// If you send in empty str nothing will happen.
 	AddContextToMessage(msgTemplatePrefix string)
+	// Combines `AddContextToMessage()` and `AddCallerToCallStack()` in one call: prepends the given piece of string to the messageTemplate and records
+	// the `layer` on the call stack - so the message growth and the call chain stay in sync. Empty inputs are no-ops (independently of each other).
+	AddContextToMessageFrom(layer string, msgTemplatePrefix string)
 	// Same as `AddContextToMessage()` (read its comment!) but with this one you can extend the audience facing messages with more context. If the audience you
 	// refer to with `forAudience` does not exist it will be created. And maybe good to know that the `msgTemplatePrefix` value in this case will be trimmed on
 	// the right side (not just whitespaces but also ':' and '-' characters) so no need to worry about strange white spaces.
 	// If you send in empty str in any parameters nothing will happen.
 	AddContextToAudienceMessage(forAudience string, msgTemplatePrefix string)
+	// The counterpart of `AddContextToMessage()` (read its comment!) but this one appends (suffixes) the piece of string to the messageTemplate instead
+	// of prepending it. So you have the choice where your context lands in the final message.
+	// It is really a suffix - imagine a simple concatenation! So you need to include separators, white-spaces etc at the beginning of your suffix str!
+	// If you send in empty str nothing will happen.
+	AppendContextToMessage(msgTemplateSuffix string)
+	// The counterpart of `AddContextToAudienceMessage()` (read its comment!) but this one appends (suffixes) the piece of string to the audience facing
+	// message. If the audience you refer to with `forAudience` does not exist it will be created. In this case the `msgTemplateSuffix` value will be trimmed
+	// on the left side (not just whitespaces but also ':' and '-' characters).
+	// If you send in empty str in any parameters nothing will happen.
+	AppendContextToAudienceMessage(forAudience string, msgTemplateSuffix string)
+	// An explicit cleanup step: removes every audience message template which has variable placeholders without a corresponding label - so serialization
+	// never emits half-resolved audience messages. This typically comes handy after `AddContextToAudienceMessage()` introduced new {var}-s which could not
+	// be backed with labels (see `AddLabels()`). Audience templates which can be fully resolved are kept, the default message template is never touched.
+	PruneUnresolvableAudienceMessages()
 	// Please read the comment of `AddContextToMessage()` method! You get a better understanding on the motivation and problem then.
 	// With this method - as the error bubbles upwards - highler level layers might want to extend it with their custom error codes. You can do it in one go by
-	// adding multiple at once.
+	// adding multiple at once. Codes are trimmed (whitespaces) and empty codes are simply ignored.
 	AddErrorCodes(c ...string)
 	// Please read the comment of `AddContextToMessage()` method! You get a better understanding on the motivation and problem then.
 	// As the error bubbles upwards higher level layers might want to extend it with more labels - especially since we have `AddContextToMessage()` and
@@ -236,16 +585,33 @@ type Fault interface {
 	// As the error bubbles upwards higher level layers might want to extend it with more labels - especially since we have `AddContextToMessage()` and
 	// `AddContextToAudienceMessage()` which can introduce new {var}-s into the messages.
 	AddLabels(labels map[string]any)
+	// As the error bubbles upwards higher level layers might want to categorize it further - with this method you can add a tag to it. Tags are a set (adding
+	// an existing tag has no effect) and trimmed (whitespaces) - empty tag is simply ignored. See also builder method `WithTags()`.
+	AddTag(tag string)
 
 	// Returns the HTTP status code you should use in the response if you fail from this Fault.
 	// Note: this is a wrapper around the utility function `GetHttpStatusCodeForFault()` - you can use that if you prefer that form instead.
 	// IMPORTANT! In case the `Fault` is not public then it is always 500 INTERNAL ERROR - otherwise it is determined from the attributes and the kind of the
-	// Fault.
+	// Fault. Non-fatal Faults (see `IsNonFatal()`) are the exception - they always get 200 OK.
 	GetHttpStatusCode() int
+	// Same as `GetHttpStatusCode()` but this one also walks the cause chain and looks into the public Faults there too - and returns the most client-meaningful
+	// status code it finds (4xx is preferred over 5xx). Useful e.g. if a `ValidationFault` was wrapped into a `RuntimeFault` - then you still get 400 instead of 500.
+	// Note: this is a wrapper around the utility function `GetHttpStatusCodeFromChainForFault()` - you can use that if you prefer that form instead.
+	// IMPORTANT! In case the `Fault` itself is not public then it is still always 500 INTERNAL ERROR.
+	GetHttpStatusCodeFromChain() int
 	// Returns the gRPC status code you should use in the response if you fail from this Fault.
 	// Note: this is a wrapper around the utility function `GetGrpcStatusCodeForFault()` - you can use that if you prefer that form instead.
 	// IMPORTANT! In case the `Fault` is not public then it is always INTERNAL error - otherwise it is determined from the attributes and the kind of the Fault.
 	GetGrpcStatusCode() codes.Code
+	// Returns the gRPC status you should respond with if you fail from this Fault - see `GetGrpcStatusCode()`. The message of the status is the resolved
+	// message of the Fault - in case the `Fault` is not public then the message is empty (so nothing can leak).
+	// Note: this is a wrapper around the utility function `GetGrpcStatusForFault()` - you can use that if you prefer that form instead.
+	ToGrpcStatus() *status.Status
+	// Returns a minimal, read-only view of this Fault which is safe to hand over to layers which should only ever see public-safe data (e.g. a templating
+	// layer rendering an error page). The view contains no cause, no labels and no internal fields at all - so it can not leak no matter how it is used.
+	// In case the `Fault` is not public then the view is the generic form the public conversion gives (see `Sanitize()`) - only the retryable flag is
+	// inherited.
+	PublicView() FaultView
 
 	// Returns the natural (most human readable) JSON form of this Fault - can come handy if you build e.g. HTTP APIs and you need quickly return an error
 	// response. Check the available `SerializationOption`s you can use optionally!
@@ -260,10 +626,13 @@ type Fault interface {
 	//           "key1": <value1>,
 	//           "key2": <value2>,
 	//           ...
-	//        }
+	//        },
+	//       "reference": "<the reference - if the Fault has one>",
+	//       "helpUrl": "<the help url - if the Fault has one>"
 	//    }
 	//
-	// As you see really internal details like "cause" or "call stack" etc are absolutely not revealed.
+	// As you see really internal details like "cause" or "call stack" etc are absolutely not revealed. The keys can be overridden if your clients expect
+	// different ones - see `SetNaturalJSONFieldNames()`.
 	//
 	// IMPORTANT! To prevent accidental data leak this serialization only renders public Faults! If the Fault is non-public you get back empty
 	// values only - unless you explicitly use `AllowNonPublicSerialization` option!
@@ -272,19 +641,113 @@ type Fault interface {
 	// - `forAudience` - if you pass empty string you get back the default MessageTemplate - otherwise the specific audience message comes back
 	ToNaturalJSON(forAudience string, options ...SerializationOption) ([]byte, error)
 
+	// Returns the natural JSON form of this Fault (see `ToNaturalJSON()`) extended with its "severity" - meant to be embedded into the "warnings" array of a
+	// successful response payload (e.g. deprecation notices in a partial-success response). Typically used with non-fatal Faults - see `IsNonFatal()`.
+	//
+	// The same public guard applies as with `ToNaturalJSON()`.
+	ToWarningJSON(forAudience string, options ...SerializationOption) ([]byte, error)
+
 	// Just like `ToNaturalJSON()` this also returns a JSON representation but this one returns the "message" and "messagesByAudience"
 	// separately - revealing more internal structure.
 	//
 	// However really internal details like "cause" or "call stack" etc are absolutely not revealed even in this form.
 	//
+	// The JSON also contains a "schemaVersion" field (see `FULLJSON_SCHEMA_VERSION`) so consumers can handle the evolution of the format.
+	//
+	// Please note: if you simply `json.Marshal()` a Fault (e.g. embedded into a bigger response struct) you get the `ToNaturalJSON("")` form - so this
+	// richer form must be requested explicitly by using this method.
+	//
 	// IMPORTANT! To prevent accidental data leak this serialization only renders public Faults! If the Fault is non-public you get back empty
 	// values only - unless you explicitly use `AllowNonPublicSerialization` option!
 	ToFullJSON(options ...SerializationOption) ([]byte, error)
+	// Returns a JSON form tailored for client side localization (i18n) - the message templates are deliberately NOT resolved and the labels are fully
+	// present, so the frontend can pick a locale and resolve the placeholders itself. This method returns a JSON like:
+	//
+	//    {
+	//       "kind": "<the Kind>",
+	//       "messageTemplate": "<the default MessageTemplate - unresolved>",
+	//       "messagesByAudience": {
+	//           "<audience>": "<the template - unresolved>",
+	//           ...
+	//        },
+	//       "labels": {
+	//           "key1": <value1>,
+	//           ...
+	//        },
+	//       "errorCodes": ["the", "error", "codes"]
+	//    }
+	//
+	// The `ResolveMessages` and `LeaveMessageVarsInLabels` options have no effect here - `OnlyAudiences()` and `PrettyPrint` can be used.
+	//
+	// IMPORTANT! The same non-public guard applies as in `ToNaturalJSON()`!
+	ToI18NJSON(options ...SerializationOption) ([]byte, error)
+	// Streaming counterpart of `ToNaturalJSON()` (read its comment!) - writes the very same JSON directly into the writer (e.g. an `http.ResponseWriter` or a
+	// log buffer) without allocating an intermediate byte slice. Just like `json.Encoder` it terminates the JSON with a newline.
+	// IMPORTANT! The same non-public guard applies as in `ToNaturalJSON()`!
+	WriteNaturalJSON(w io.Writer, forAudience string, options ...SerializationOption) error
+	// Streaming counterpart of `ToFullJSON()` (read its comment!) - writes the very same JSON directly into the writer without allocating an intermediate
+	// byte slice. Just like `json.Encoder` it terminates the JSON with a newline.
+	// IMPORTANT! The same non-public guard applies as in `ToFullJSON()`!
+	WriteFullJSON(w io.Writer, options ...SerializationOption) error
+
+	// Returns a JSON payload suitable to be used as the `data` field of a CloudEvents event - useful in event-driven systems. The payload is the natural form
+	// (see `ToNaturalJSON()` - messages are resolved) extended with a "type" field derived from the kind, like:
+	//
+	//    {
+	//       "type": "com.keytiles.fault.<the Kind>",
+	//       "kind": "<the Kind>",
+	//       "message": "<the resolved default message>",
+	//       ...
+	//    }
+	//
+	// IMPORTANT! Just like `ToNaturalJSON()` this only renders public Faults! If the Fault is non-public you get back the empty values only.
+	ToCloudEventData() ([]byte, error)
+
+	// Returns the Fault as a flat map of fields - ready to be plugged into structured loggers expecting a field map. The fields are:
+	//   - "kind", "retryable", "errorCodes" and "source" - always
+	//   - "reference" - if the Fault has one
+	//   - "message" - the resolved default message, only for public Faults
+	//   - "label.<key>" - the labels flattened with "label." prefix, only for public Faults
+	//
+	// IMPORTANT! Just like the serializations this honors the public flag: non-public Faults omit the labels and the message as they might carry
+	// sensitive data. For the `NoFault` sentinel an empty map is returned.
+	ToLogFields() map[string]any
+
+	// Renders this Fault into text with the given Go `text/template` - so each service can define its own log line format. The template gets a
+	// `FaultRenderData` (Kind, Message, Codes, Retryable, Source, Labels). If you pass nil then the default template is used (see
+	// `SetDefaultRenderTemplate()`). The error is coming from the template execution.
+	//
+	// IMPORTANT! This honors the public flag too: for non-public Faults the label values are redacted and the Message is the unresolved message template
+	// (so the label values can not leak through the message either).
+	Render(tmpl *template.Template) (string, error)
+
+	// The multi-line counterpart of `String()` - renders the Fault as an indented tree. Fault causes are rendered recursively with increasing
+	// indentation, so richly populated Faults with nested causes are easier to read e.g. in logs or while debugging.
+	// This is purely a human-readability helper - the format is not meant to be parsed.
+	StringIndented() string
+
+	// Returns a copy of this Fault but with a different kind. Everything else - message templates, error codes, labels, cause etc - is preserved.
+	// This comes handy if you want to re-classify a Fault e.g. at a boundary (turning an `IllegalStateFault` into a `ValidationFault` e.g.) without
+	// rebuilding it from scratch.
+	// **Note:** the retryability rules are re-evaluated for the new kind - so if the new kind is inheritedly not retryable the copy will not be retryable either.
+	// The original Fault remains untouched.
+	WithKindOverride(kind FaultKind) Fault
+	// Returns a copy of this Fault which is not public anymore - so it gets the redacted treatment everywhere (blank form in serialization, no labels in
+	// `Error()` etc). This is the inverse of the public conversion: handy if you realize a public Fault carries sensitive labels (e.g. added later, in a
+	// deeper layer) and you want to re-seal it before logging or returning it - safer than rebuilding it from scratch.
+	// The original Fault remains untouched.
+	AsNonPublic() Fault
+
+	// Logs this Fault (in its complete `String()` form) with the given level - using the logger attached with the builder method `WithLogger()` or the default
+	// logger of this library if there is no attached logger. This supports the "log once at the boundary" pattern: the layer creating the Fault knows which
+	// logger should record it, while the logging itself can happen later (or only if the Fault remained unhandled) without threading loggers through every function.
+	LogSelf(level kt_logging.LogLevel)
 }
 
 func newInitializedFault(errType FaultKind) defaultFault {
 	return defaultFault{
-		Kind: errType,
+		Kind:       errType,
+		InstanceId: generateInstanceId(),
 		// lets keep these on Nil until first used
 		//Labels:                     make(map[string]any, 0),
 		//MessageTemplatesByAudience: make(map[string]string, 0),
@@ -292,6 +755,70 @@ func newInitializedFault(errType FaultKind) defaultFault {
 	}
 }
 
+// Makes a deep copy of the Fault - so mutating the returned instance does not affect the original one.
+func (fault *defaultFault) copy() *defaultFault {
+	ret := *fault
+	if fault.MessageTemplatesByAudience != nil {
+		ret.MessageTemplatesByAudience = fault.GetMessageTemplatesByAudience()
+	}
+	if fault.ErrorCodes != nil {
+		ret.ErrorCodes = fault.GetErrorCodes()
+	}
+	if fault.Labels != nil {
+		ret.Labels = fault.GetLabels()
+	}
+	ret.Tags = slices.Clone(fault.Tags)
+	if fault.properties != nil {
+		ret.properties = maps.Clone(fault.properties)
+	}
+	ret.callStack = slices.Clone(fault.callStack)
+	ret.publicLabelKeys = slices.Clone(fault.publicLabelKeys)
+	ret.causes = slices.Clone(fault.causes)
+	ret.errorCodeCategories = maps.Clone(fault.errorCodeCategories)
+	// a copy is never pooled - it must not be released
+	ret.poolGeneration = 0
+	return &ret
+}
+
+// Certain kinds (see `SetKindRetryabilityPolicy()`) and kinds combined with certain error codes are inheritedly not retryable. This method is resetting
+// the retryable flag according to these rules.
+func (fault *defaultFault) applyRetryabilityRules() {
+	if !fault.Retryable || fault.retryableForced {
+		return
+	}
+	if reason := fault.retryabilityDeniedReason(); reason != "" {
+		fault.Retryable = false
+		fault.denyRetryability(reason)
+	}
+}
+
+// Returns why the Fault is not allowed to be retryable - or empty string if nothing prevents it.
+func (fault *defaultFault) retryabilityDeniedReason() string {
+	if !IsKindRetryabilityAllowed(fault.Kind) {
+		return fmt.Sprintf("kind %s is inherently non-retryable", fault.Kind)
+	}
+	switch fault.Kind {
+	case AuthenticationFault:
+		if fault.HasErrorCode(AUTHENTICATION_ERRCODE_MISSING) {
+			return "authentication is missing"
+		}
+		if fault.HasErrorCode(AUTHENTICATION_ERRCODE_NOT_SUPPORTED) {
+			return "auth method not supported"
+		}
+	case AuthorizationFault:
+		if fault.HasErrorCode(AUTHORIZATION_NO_PERMISSION) {
+			return "no permission"
+		}
+	}
+	return ""
+}
+
+// Records that retryability was requested but denied for the given reason - see `RetryabilityReason()`.
+func (fault *defaultFault) denyRetryability(reason string) {
+	fault.retryabilityReason = reason
+	fault.retryabilityDenied = true
+}
+
 // This is used only for JSON / Yaml serialization
 type naturalFormFault struct {
 	Kind       FaultKind      `json:"kind" yaml:"kind"`
@@ -299,6 +826,10 @@ type naturalFormFault struct {
 	Retryable  bool           `json:"isRetryable" yaml:"isRetryable"`
 	ErrorCodes []string       `json:"errorCodes" yaml:"errorCodes"`
 	Labels     map[string]any `json:"labels" yaml:"labels"`
+	Reference  string         `json:"reference,omitempty" yaml:"reference,omitempty"`
+	HelpUrl    string         `json:"helpUrl,omitempty" yaml:"helpUrl,omitempty"`
+	// only with `IncludeCause` option - either the natural form of a Fault cause (as raw JSON) or the error string of other errors
+	Cause any `json:"cause,omitempty" yaml:"cause,omitempty"`
 }
 
 type defaultFault struct {
@@ -308,49 +839,175 @@ type defaultFault struct {
 	Retryable                  bool              `json:"isRetryable" yaml:"isRetryable"`
 	ErrorCodes                 []string          `json:"errorCodes" yaml:"errorCodes"`
 	Labels                     map[string]any    `json:"labels" yaml:"labels"`
+	Reference                  string            `json:"reference,omitempty" yaml:"reference,omitempty"`
+	HelpUrl                    string            `json:"helpUrl,omitempty" yaml:"helpUrl,omitempty"`
+	InstanceId                 string            `json:"instanceId,omitempty" yaml:"instanceId,omitempty"`
+	Tags                       []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Severity                   Severity          `json:"severity,omitempty" yaml:"severity,omitempty"`
 	properties                 map[string]any
 	public                     bool
-	cause                      error
+	errorCodeCategories        map[string]string
+	publicLabelKeys            []string
+	causes                     []error
 	callStack                  []string
+	// the logger `LogSelf()` is using - never serialized
+	logger *kt_logging.Logger
+	// renders the label values during message resolution - see `WithLabelFormatter()` builder method
+	labelFormatter func(key string, value any) string
+	// explains why the Fault is (not) retryable - see `RetryabilityReason()`
+	retryabilityReason string
+	// true if retryability was requested but the rules denied it
+	retryabilityDenied bool
+	// true if the retryable flag was forced - bypassing the rules (see `WithForcedRetryable()` builder method)
+	retryableForced bool
+	// memoized result of `GetMessage()` - only built Faults have it (see `Build()`), the builder does not need it
+	resolvedMessage    string
+	hasResolvedMessage bool
+	// only used by pooled Faults (see `NewPooledFaultBuilder()`) - odd while the Fault is in use and even once it was released, 0 for not pooled Faults
+	poolGeneration uint32
+}
+
+// As a Fault's message is typically read multiple times (logged, serialized, returned) it is memoized - resolving the template every time would be
+// wasteful. The methods changing the message template or the labels (`AddLabel()`, `AddContextToMessage()` etc) must invoke this to keep it up to date -
+// but only if the change can affect the message (e.g. a label which is not referred by the template can not).
+//
+// Please note: the memoized message is kept up to date eagerly (not invalidated and resolved lazily in `GetMessage()`) - so reading a Fault never
+// writes it, and two equal Faults remain equal no matter which one was read already.
+func (fault *defaultFault) refreshResolvedMessage() {
+	if fault.hasResolvedMessage {
+		fault.resolvedMessage = fault.resolveTemplate(fault.MessageTemplate, fault.Labels)
+	}
 }
 
 func (fault *defaultFault) GetKind() FaultKind {
-	if fault == nil {
+	if fault.isNil() {
 		return ""
 	}
 	return fault.Kind
 }
 
 func (fault *defaultFault) GetMessageTemplate() string {
-	if fault == nil {
+	if fault.isNil() {
 		return ""
 	}
 	return fault.MessageTemplate
 }
 
 func (fault *defaultFault) GetMessage() string {
-	if fault == nil {
+	if fault.isNil() {
 		return ""
 	}
-	return kt_utils.StringSimpleResolve(fault.MessageTemplate, fault.Labels)
+	if fault.hasResolvedMessage {
+		return fault.resolvedMessage
+	}
+	return fault.resolveTemplate(fault.MessageTemplate, fault.Labels)
+}
+
+func (fault *defaultFault) AppendMessage(dst []byte) []byte {
+	return append(dst, fault.GetMessage()...)
 }
 
 func (fault *defaultFault) GetMessageTemplateForAudience(forAudience string) string {
-	if fault == nil || fault.MessageTemplatesByAudience == nil {
+	if fault.isNil() || fault.MessageTemplatesByAudience == nil {
 		return ""
 	}
 	return fault.MessageTemplatesByAudience[forAudience]
 }
 
 func (fault *defaultFault) GetMessageForAudience(forAudience string) string {
-	if fault == nil || fault.MessageTemplatesByAudience == nil {
+	if fault.isNil() || fault.MessageTemplatesByAudience == nil {
+		return ""
+	}
+	return fault.resolveTemplate(fault.GetMessageTemplateForAudience(forAudience), fault.Labels)
+}
+
+func (fault *defaultFault) GetMessageForAudienceOrDefault(forAudience string) string {
+	if fault.isNil() {
+		return ""
+	}
+	if _, found := fault.MessageTemplatesByAudience[forAudience]; found {
+		return fault.GetMessageForAudience(forAudience)
+	}
+	return fault.GetMessage()
+}
+
+func (fault *defaultFault) GetMessagePreferred(audiences ...string) string {
+	if fault.isNil() {
+		return ""
+	}
+	for _, audience := range audiences {
+		if msg := fault.GetMessageForAudience(audience); msg != "" {
+			return msg
+		}
+	}
+	return fault.GetMessage()
+}
+
+func (fault *defaultFault) GetMessageWith(extraLabels map[string]any) string {
+	if fault.isNil() {
+		return ""
+	}
+	return fault.resolveTemplate(fault.MessageTemplate, fault.labelsMergedWith(extraLabels))
+}
+
+func (fault *defaultFault) GetMessageForAudienceWith(forAudience string, extraLabels map[string]any) string {
+	if fault.isNil() || fault.MessageTemplatesByAudience == nil {
 		return ""
 	}
-	return kt_utils.StringSimpleResolve(fault.GetMessageTemplateForAudience(forAudience), fault.Labels)
+	return fault.resolveTemplate(fault.GetMessageTemplateForAudience(forAudience), fault.labelsMergedWith(extraLabels))
+}
+
+func (fault *defaultFault) TemplateVariables() []string {
+	return slices.Sorted(maps.Keys(fault.TemplateVariableCounts()))
+}
+
+func (fault *defaultFault) TemplateVariableCounts() map[string]int {
+	counts := make(map[string]int)
+	if fault.isNil() {
+		return counts
+	}
+	countVariables := func(template string) {
+		for _, match := range kt_utils.VARIABLE_MATCHER.FindAllStringSubmatch(template, -1) {
+			if len(match) > 1 {
+				counts[match[1]]++
+			}
+		}
+	}
+	countVariables(fault.MessageTemplate)
+	for _, template := range fault.MessageTemplatesByAudience {
+		countVariables(template)
+	}
+	return counts
+}
+
+// Resolves the variable placeholders of the template from the given labels - using the label formatter if there is one. Placeholders without label
+// are left unchanged.
+func (fault *defaultFault) resolveTemplate(template string, labels map[string]any) string {
+	if fault.labelFormatter == nil {
+		return kt_utils.StringSimpleResolve(template, labels)
+	}
+	return kt_utils.VARIABLE_MATCHER.ReplaceAllStringFunc(template, func(match string) string {
+		key := kt_utils.VARIABLE_MATCHER.FindStringSubmatch(match)[1]
+		if value, found := labels[key]; found {
+			return fault.labelFormatter(key, value)
+		}
+		return match
+	})
+}
+
+// Returns the labels merged with the given extra labels (extra ones win) - without touching the labels of the Fault.
+func (fault *defaultFault) labelsMergedWith(extraLabels map[string]any) map[string]any {
+	if len(extraLabels) == 0 {
+		return fault.Labels
+	}
+	merged := make(map[string]any, len(fault.Labels)+len(extraLabels))
+	maps.Copy(merged, fault.Labels)
+	maps.Copy(merged, extraLabels)
+	return merged
 }
 
 func (fault *defaultFault) GetMessageTemplatesByAudience() map[string]string {
-	if fault == nil || fault.MessageTemplatesByAudience == nil {
+	if fault.isNil() || fault.MessageTemplatesByAudience == nil {
 		return make(map[string]string)
 	}
 	// we return a copy only
@@ -359,15 +1016,102 @@ func (fault *defaultFault) GetMessageTemplatesByAudience() map[string]string {
 	return ret
 }
 
+func (fault *defaultFault) GetSeverity() Severity {
+	if fault.isNil() {
+		return SeverityError
+	}
+	return fault.Severity
+}
+
+func (fault *defaultFault) IsNonFatal() bool {
+	return fault.GetSeverity() != SeverityError
+}
+
+func (fault *defaultFault) AudienceNames() []string {
+	if fault.isNil() {
+		return make([]string, 0)
+	}
+	ret := make([]string, 0, len(fault.MessageTemplatesByAudience))
+	for name := range fault.MessageTemplatesByAudience {
+		ret = append(ret, name)
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+func (fault *defaultFault) HasAudience(name string) bool {
+	if fault.isNil() {
+		return false
+	}
+	_, found := fault.MessageTemplatesByAudience[name]
+	return found
+}
+
+func (fault *defaultFault) IsClientError() bool {
+	if fault.isNil() {
+		return false
+	}
+	return KindCategory(fault.Kind) == KINDCATEGORY_CLIENT
+}
+
+func (fault *defaultFault) IsServerError() bool {
+	if fault.isNil() {
+		return false
+	}
+	return KindCategory(fault.Kind) == KINDCATEGORY_SERVER
+}
+
+func (fault *defaultFault) GetReference() string {
+	if fault.isNil() {
+		return ""
+	}
+	return fault.Reference
+}
+
+func (fault *defaultFault) GetHelpUrl() string {
+	if fault.isNil() {
+		return ""
+	}
+	return fault.HelpUrl
+}
+
+func (fault *defaultFault) GetFingerprint() string {
+	if fault.isNil() {
+		return ""
+	}
+	parts := append([]string{fault.Kind, fault.MessageTemplate}, slices.Sorted(slices.Values(fault.ErrorCodes))...)
+	return fingerprintOf(parts...)
+}
+
+// Computes a short, stable fingerprint (8 hex chars) from the given parts - see `fault.GetFingerprint()`.
+func fingerprintOf(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return strings.ToUpper(hex.EncodeToString(sum[:4]))
+}
+
+func (fault *defaultFault) GetInstanceId() string {
+	if fault.isNil() {
+		return ""
+	}
+	return fault.InstanceId
+}
+
 func (fault *defaultFault) IsPublic() bool {
-	if fault == nil {
+	if fault.isNil() {
 		return false
 	}
 	return fault.public
 }
 
+func (fault *defaultFault) GetErrorCodesString() string {
+	if fault.isNil() || len(fault.ErrorCodes) == 0 {
+		return "[]"
+	}
+	return fmt.Sprintf("['%s']", strings.Join(slices.Sorted(slices.Values(fault.ErrorCodes)), "','"))
+}
+
 func (fault *defaultFault) GetErrorCodes() []string {
-	if fault == nil || fault.ErrorCodes == nil {
+	if fault.isNil() || fault.ErrorCodes == nil {
 		// we return empty
 		return make([]string, 0)
 	}
@@ -378,7 +1122,7 @@ func (fault *defaultFault) GetErrorCodes() []string {
 }
 
 func (fault *defaultFault) HasErrorCode(codes ...string) bool {
-	if fault == nil || fault.ErrorCodes == nil {
+	if fault.isNil() || fault.ErrorCodes == nil {
 		return false
 	}
 	for _, code := range codes {
@@ -389,15 +1133,131 @@ func (fault *defaultFault) HasErrorCode(codes ...string) bool {
 	return false
 }
 
+func (fault *defaultFault) HasAllErrorCodes(codes ...string) bool {
+	if fault.isNil() {
+		return len(codes) == 0
+	}
+	for _, code := range codes {
+		if !slices.Contains(fault.ErrorCodes, code) {
+			return false
+		}
+	}
+	return true
+}
+
+func (fault *defaultFault) HasExactErrorCodes(codes ...string) bool {
+	if !fault.HasAllErrorCodes(codes...) {
+		return false
+	}
+	// the error codes of the Fault are a set - so if all of them are listed then the two sets are equal
+	for _, code := range fault.ErrorCodes {
+		if !slices.Contains(codes, code) {
+			return false
+		}
+	}
+	return true
+}
+
+func (fault *defaultFault) GetStructuredErrorCodes() []ErrorCode {
+	if fault.isNil() {
+		return make([]ErrorCode, 0)
+	}
+	ret := make([]ErrorCode, len(fault.ErrorCodes))
+	for i, code := range fault.ErrorCodes {
+		ret[i] = ErrorCode{Category: fault.errorCodeCategories[code], Code: code}
+	}
+	return ret
+}
+
 func (fault *defaultFault) GetCause() error {
-	if fault == nil {
+	if fault.isNil() {
+		return nil
+	}
+	if len(fault.causes) == 0 {
+		return nil
+	}
+	return fault.causes[0]
+}
+
+func (fault *defaultFault) GetCauseFault() (Fault, bool) {
+	isFault, causeFault := IsFault(fault.GetCause())
+	return causeFault, isFault
+}
+
+func (fault *defaultFault) GetFaultCauseChain() []Fault {
+	ret := make([]Fault, 0)
+	if fault.isNil() {
+		return ret
+	}
+	walkErrorTree(fault, func(err error, depth int) bool {
+		if isFault, causeFault := IsFault(err); isFault && depth > 1 {
+			ret = append(ret, causeFault)
+		}
+		return true
+	})
+	return ret
+}
+
+func (fault *defaultFault) ChainSummary() (depth int, faultCount int, hasNonPublic bool) {
+	if fault.isNoFault() {
+		return 0, 0, false
+	}
+	walkErrorTree(fault, func(err error, linkDepth int) bool {
+		depth = max(depth, linkDepth)
+		if isFault, linkFault := IsFault(err); isFault {
+			faultCount++
+			hasNonPublic = hasNonPublic || !linkFault.IsPublic()
+		} else {
+			hasNonPublic = true
+		}
+		return true
+	})
+	return depth, faultCount, hasNonPublic
+}
+
+func (fault *defaultFault) GetCauses() []error {
+	if fault.isNil() || fault.causes == nil {
+		// we return empty
+		return make([]error, 0)
+	}
+	// we return a copy
+	return slices.Clone(fault.causes)
+}
+
+func (fault *defaultFault) WalkCauses(fn func(err error) bool) {
+	if fault.isNil() {
+		return
+	}
+	WalkErrorChain(fault, fn)
+}
+
+// This is the Go 1.20 multi-unwrap form - so `errors.Is()` and `errors.As()` can check all the causes of the Fault.
+func (fault *defaultFault) Unwrap() []error {
+	if fault.isNil() {
 		return nil
 	}
-	return fault.cause
+	return fault.causes
+}
+
+// This makes sentinel matching with `errors.Is()` possible. You can define package level sentinel Faults like
+//
+//	var ErrConfigInvalid = NewPublicFaultBuilder(IllegalStateFault).WithErrorCodes(ILLEGALSTATE_ERRCODE_CONFIG_ERROR).Build()
+//
+// and then `errors.Is(err, ErrConfigInvalid)` is true if `err` (or any error in its chain) is a Fault with the same kind sharing at least one error code
+// with the sentinel. Please note: a sentinel without error codes only matches itself (identity).
+func (fault *defaultFault) Is(target error) bool {
+	if fault.isNoFault() {
+		return false
+	}
+	isFault, targetFault := IsFault(target)
+	if !isFault || IsNoFault(targetFault) || targetFault.GetKind() != fault.Kind {
+		return false
+	}
+	return slices.ContainsFunc(fault.ErrorCodes, func(code string) bool { return targetFault.HasErrorCode(code) })
 }
 
 func (fault *defaultFault) GetSource() string {
-	if fault == nil {
+	if fault.isNil() {
 		return ""
 	}
 	if len(fault.callStack) > 0 {
@@ -407,7 +1267,7 @@ func (fault *defaultFault) GetSource() string {
 }
 
 func (fault *defaultFault) GetCallStack() []string {
-	if fault == nil {
+	if fault.isNil() {
 		return make([]string, 0)
 	}
 	// we return a copy
@@ -418,29 +1278,103 @@ func (fault *defaultFault) GetCallStack() []string {
 }
 
 func (fault *defaultFault) AddCallerToCallStack(caller ...string) {
-	if fault == nil {
+	if fault.isNoFault() {
 		return
 	}
 	fault.callStack = append(fault.callStack, strings.Join(caller, "."))
 }
 
 func (fault *defaultFault) IsRetryable() bool {
-	if fault == nil {
+	if fault.isNil() {
 		return false
 	}
 	return fault.Retryable
 }
 
-func (fault *defaultFault) GetLabel(key string) (value any, found bool) {
-	if fault == nil || fault.Labels == nil || key == "" {
-		return
+func (fault *defaultFault) RetryabilityReason() string {
+	if fault.isNil() {
+		return ""
 	}
-	value, found = fault.Labels[key]
-	return
+	return fault.retryabilityReason
+}
+
+func (fault *defaultFault) IsRetryableInChain() bool {
+	if fault.isNil() {
+		return false
+	}
+	retryable := false
+	WalkErrorChain(fault, func(err error) bool {
+		isFault, chainFault := IsFault(err)
+		retryable = isFault && chainFault.IsRetryable()
+		return !retryable
+	})
+	return retryable
+}
+
+func (fault *defaultFault) GetLabel(key string) (value any, found bool) {
+	if fault.isNil() || fault.Labels == nil || key == "" {
+		return
+	}
+	value, found = fault.Labels[key]
+	return
+}
+
+func (fault *defaultFault) GetLatency() (latency time.Duration, found bool) {
+	return fault.getMillisLabelAsDuration(latencyLabel)
+}
+
+func (fault *defaultFault) GetTimeLabel(key string) (value time.Time, found bool) {
+	label, found := fault.GetLabel(key)
+	if !found {
+		return
+	}
+	switch t := label.(type) {
+	case time.Time:
+		value = t
+	case string:
+		parsed, err := time.Parse(time.RFC3339, t)
+		value, found = parsed, err == nil
+	default:
+		found = false
+	}
+	return
+}
+
+func (fault *defaultFault) GetRetryAfter() (retryAfter time.Duration, found bool) {
+	return fault.getMillisLabelAsDuration(retryAfterLabel)
+}
+
+// Reads back a duration stored in milliseconds as label.
+func (fault *defaultFault) getMillisLabelAsDuration(key string) (d time.Duration, found bool) {
+	value, found := fault.GetLabel(key)
+	if !found {
+		return
+	}
+	switch ms := value.(type) {
+	case int64:
+		d = time.Duration(ms) * time.Millisecond
+	case int:
+		d = time.Duration(ms) * time.Millisecond
+	case float64:
+		// this is what we get back e.g. after a JSON roundtrip
+		d = time.Duration(ms * float64(time.Millisecond))
+	default:
+		found = false
+	}
+	return
+}
+
+func (fault *defaultFault) GetPublicLabelKeys() []string {
+	if fault.isNil() || fault.publicLabelKeys == nil {
+		// we return empty
+		return make([]string, 0)
+	}
+	// we return a copy
+	return slices.Clone(fault.publicLabelKeys)
 }
 
 func (fault *defaultFault) GetLabels() map[string]any {
-	if fault == nil || fault.Labels == nil {
+	if fault.isNil() || fault.Labels == nil {
 		// we return empty map
 		return make(map[string]any)
 	}
@@ -450,21 +1384,68 @@ func (fault *defaultFault) GetLabels() map[string]any {
 	return ret
 }
 
+func (fault *defaultFault) GetLabelsDeep() map[string]any {
+	ret := fault.GetLabels()
+	if fault.isNil() {
+		return ret
+	}
+	conflicts := make(map[string][]any)
+	walkErrorTree(fault, func(err error, depth int) bool {
+		isFault, causeFault := IsFault(err)
+		if !isFault || depth == 1 {
+			return true
+		}
+		for key, value := range causeFault.GetLabels() {
+			existing, found := ret[key]
+			if !found {
+				ret[key] = value
+			} else if !reflect.DeepEqual(existing, value) {
+				if _, recorded := conflicts[key]; !recorded {
+					conflicts[key] = []any{existing}
+				}
+				if !slices.ContainsFunc(conflicts[key], func(v any) bool { return reflect.DeepEqual(v, value) }) {
+					conflicts[key] = append(conflicts[key], value)
+				}
+			}
+		}
+		return true
+	})
+	if len(conflicts) > 0 {
+		ret[labelConflictsKey] = conflicts
+	}
+	return ret
+}
+
 func (fault *defaultFault) AddContextToMessage(contextMsgTemplate string) {
-	if fault == nil {
+	if fault.isNoFault() {
 		return
 	}
 	if contextMsgTemplate != "" {
 		// we prepend to the message
 		fault.MessageTemplate = contextMsgTemplate + fault.MessageTemplate
+		fault.refreshResolvedMessage()
+	}
+}
+
+func (fault *defaultFault) AddContextToMessageFrom(layer string, contextMsgTemplate string) {
+	if fault.isNoFault() {
+		return
+	}
+	fault.AddContextToMessage(contextMsgTemplate)
+	if layer != "" {
+		fault.AddCallerToCallStack(layer)
 	}
 }
 
 func (fault *defaultFault) AddContextToAudienceMessage(forAudience string, contextMsgTemplate string) {
-	if fault == nil {
+	if fault.isNoFault() {
 		return
 	}
 	if contextMsgTemplate != "" && forAudience != "" {
+		// lets lazy-create map if not created yet
+		if fault.MessageTemplatesByAudience == nil {
+			fault.MessageTemplatesByAudience = make(map[string]string)
+		}
 		_trimmed := ""
 		msg, found := fault.MessageTemplatesByAudience[forAudience]
 		if found {
@@ -483,14 +1464,63 @@ func (fault *defaultFault) AddContextToAudienceMessage(forAudience string, conte
 	}
 }
 
+func (fault *defaultFault) AppendContextToMessage(contextMsgTemplate string) {
+	if fault.isNoFault() {
+		return
+	}
+	if contextMsgTemplate != "" {
+		// we append to the message
+		fault.MessageTemplate = fault.MessageTemplate + contextMsgTemplate
+		fault.refreshResolvedMessage()
+	}
+}
+
+func (fault *defaultFault) AppendContextToAudienceMessage(forAudience string, contextMsgTemplate string) {
+	if fault.isNoFault() {
+		return
+	}
+	if contextMsgTemplate != "" && forAudience != "" {
+		// lets lazy-create map if not created yet
+		if fault.MessageTemplatesByAudience == nil {
+			fault.MessageTemplatesByAudience = make(map[string]string)
+		}
+		msg, found := fault.MessageTemplatesByAudience[forAudience]
+		if found {
+			// we append to the message
+			fault.MessageTemplatesByAudience[forAudience] = msg + contextMsgTemplate
+		} else {
+			// will become the message but trimmed way
+			fault.MessageTemplatesByAudience[forAudience] = strings.TrimLeft(contextMsgTemplate, " \t\r\n-:")
+		}
+	}
+}
+
+func (fault *defaultFault) PruneUnresolvableAudienceMessages() {
+	if fault.isNoFault() {
+		return
+	}
+	for audience, template := range fault.MessageTemplatesByAudience {
+		for _, variable := range kt_utils.StringExtractVariableNames(template).GetAll() {
+			if _, found := fault.Labels[variable]; !found {
+				delete(fault.MessageTemplatesByAudience, audience)
+				break
+			}
+		}
+	}
+	if len(fault.MessageTemplatesByAudience) == 0 {
+		fault.MessageTemplatesByAudience = nil
+	}
+}
+
 func (fault *defaultFault) AddErrorCodes(c ...string) {
-	if fault == nil {
+	if fault.isNoFault() {
 		return
 	}
 	if fault.ErrorCodes == nil {
 		fault.ErrorCodes = make([]string, 0, len(c))
 	}
 	for _, errCode := range c {
+		errCode = strings.TrimSpace(errCode)
 		if errCode != "" && !slices.Contains(fault.ErrorCodes, errCode) {
 			fault.ErrorCodes = append(fault.ErrorCodes, errCode)
 		}
@@ -498,7 +1528,7 @@ func (fault *defaultFault) AddErrorCodes(c ...string) {
 }
 
 func (fault *defaultFault) AddLabel(key string, value any) {
-	if fault == nil {
+	if fault.isNoFault() {
 		return
 	}
 	if key != "" {
@@ -506,29 +1536,202 @@ func (fault *defaultFault) AddLabel(key string, value any) {
 		if fault.Labels == nil {
 			fault.Labels = make(map[string]any)
 		}
-		fault.Labels[key] = value
+		maxCount, maxValueLength := getLabelLimits()
+		if _, exists := fault.Labels[key]; !exists && maxCount > 0 && len(fault.Labels) >= maxCount {
+			getDefaultLogger().Debug("Label '%s' is dropped - the Fault already has the max allowed %d labels (see `SetMaxLabelCount()`)", key, maxCount)
+			return
+		}
+		fault.Labels[key] = truncateLabelValue(value, maxValueLength)
+		// cheap check - labels not referred by the message do not change it
+		if strings.Contains(fault.MessageTemplate, "{"+key+"}") {
+			fault.refreshResolvedMessage()
+		}
 	}
 }
 
 func (fault *defaultFault) AddLabels(labels map[string]any) {
-	if fault == nil || labels == nil {
+	if fault.isNoFault() || labels == nil {
 		return
 	}
 	// lets lazy-create map if not created yet
 	if fault.Labels == nil {
 		fault.Labels = make(map[string]any, len(labels))
 	}
-	maps.Copy(fault.Labels, labels)
+	// we refresh the memoized message only once at the end
+	hasResolvedMessage := fault.hasResolvedMessage
+	fault.hasResolvedMessage = false
+	// in sorted order - so if the label count is capped it is deterministic which labels are dropped
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		fault.AddLabel(key, labels[key])
+	}
+	fault.hasResolvedMessage = hasResolvedMessage
+	fault.refreshResolvedMessage()
+}
+
+func (fault *defaultFault) GetTags() []string {
+	if fault.isNil() || fault.Tags == nil {
+		return make([]string, 0)
+	}
+	return slices.Clone(fault.Tags)
+}
+
+func (fault *defaultFault) HasTag(tag string) bool {
+	if fault.isNil() {
+		return false
+	}
+	_, found := slices.BinarySearch(fault.Tags, tag)
+	return found
+}
+
+func (fault *defaultFault) AddTag(tag string) {
+	if fault.isNoFault() {
+		return
+	}
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return
+	}
+	// we keep them sorted - so lookup is fast and the order is deterministic
+	if idx, found := slices.BinarySearch(fault.Tags, tag); !found {
+		fault.Tags = slices.Insert(fault.Tags, idx, tag)
+	}
+}
+
+func (fault *defaultFault) ToLogFields() map[string]any {
+	if fault.isNoFault() {
+		return make(map[string]any)
+	}
+	fields := map[string]any{
+		"kind":       fault.Kind,
+		"retryable":  fault.Retryable,
+		"errorCodes": fault.GetErrorCodes(),
+		"source":     fault.GetSource(),
+	}
+	if fault.Reference != "" {
+		fields["reference"] = fault.Reference
+	}
+	if fault.public {
+		fields["message"] = fault.GetMessage()
+		for key, value := range fault.Labels {
+			fields["label."+key] = value
+		}
+	}
+	return fields
+}
+
+// Truncates the string values longer than the max length (if there is max length) - see `SetMaxLabelValueLength()`.
+func truncateLabelValue(value any, maxLength int) any {
+	if maxLength <= 0 {
+		return value
+	}
+	if strValue, isString := value.(string); isString {
+		if runes := []rune(strValue); len(runes) > maxLength {
+			return string(runes[:maxLength]) + "..."
+		}
+	}
+	return value
+}
+
+func (fault *defaultFault) WithKindOverride(kind FaultKind) Fault {
+	if fault.isNil() {
+		return nil
+	}
+	ret := fault.copy()
+	ret.Kind = kind
+	ret.applyRetryabilityRules()
+	return ret
+}
+
+func (fault *defaultFault) AsNonPublic() Fault {
+	if fault.isNil() {
+		return nil
+	}
+	if fault == noFault {
+		return NoFault
+	}
+	ret := fault.copy()
+	ret.public = false
+	return ret
 }
 
 func (fault *defaultFault) GetHttpStatusCode() int {
 	return GetHttpStatusCodeForFault(fault)
 }
 
+func (fault *defaultFault) GetHttpStatusCodeFromChain() int {
+	return GetHttpStatusCodeFromChainForFault(fault)
+}
+
+func (fault *defaultFault) PublicView() FaultView {
+	if fault == noFault {
+		return FaultView{ErrorCodes: make([]string, 0), HttpStatus: fault.GetHttpStatusCode()}
+	}
+	if fault.isNil() {
+		return FaultView{ErrorCodes: make([]string, 0), HttpStatus: GetHttpStatusCodeForFault(nil)}
+	}
+	if !fault.public {
+		// the very same generic form the public conversion gives - without the logging
+		return Sanitize(fault).PublicView()
+	}
+	return FaultView{
+		Kind:       fault.Kind,
+		Message:    fault.GetMessage(),
+		ErrorCodes: fault.GetErrorCodes(),
+		Retryable:  fault.Retryable,
+		HttpStatus: fault.GetHttpStatusCode(),
+	}
+}
+
+func (fault *defaultFault) ToGrpcStatus() *status.Status {
+	return GetGrpcStatusForFault(fault)
+}
+
 func (fault *defaultFault) GetGrpcStatusCode() codes.Code {
 	return GetGrpcStatusCodeForFault(fault)
 }
 
+// The sentinel of the `NoFault` - see there.
+var noFault = &defaultFault{}
+
+// Sentinel value representing "nothing went wrong" - so APIs can uniformly return a `Fault` and distinguish success cleanly without nil checks
+// scattered around. Use `IsNoFault()` to check it!
+//
+// The sentinel behaves like a success: `GetHttpStatusCode()` returns 200, `GetGrpcStatusCode()` returns OK and all serializations yield an empty body
+// (`json.Marshal()` yields `null`). It is immutable - the methods extending the Fault (like `AddLabel()`) have no effect on it.
+var NoFault Fault = noFault
+
+// Tells if the given Fault represents "nothing went wrong" - so it is the `NoFault` sentinel or nil.
+func IsNoFault(fault Fault) bool {
+	if fault == nil {
+		return true
+	}
+	df, ok := fault.(*defaultFault)
+	return ok && df == noFault
+}
+
+// True if this is the `NoFault` sentinel (or nil) - which must not be changed.
+func (fault *defaultFault) isNoFault() bool {
+	return fault.isNil() || fault == noFault
+}
+
+// True if the receiver is a nil Fault - the methods return empty values then. Unless `SetPanicOnNilFault()` is turned on: then it panics - to catch
+// the bugs where a nil Fault is used as an error.
+func (fault *defaultFault) isNil() bool {
+	if fault != nil {
+		return false
+	}
+	if IsPanicOnNilFault() {
+		method := "method"
+		if pc, _, _, ok := runtime.Caller(1); ok {
+			if fn := runtime.FuncForPC(pc); fn != nil {
+				method = fn.Name()[strings.LastIndex(fn.Name(), ".")+1:] + "()"
+			}
+		}
+		panic(fmt.Sprintf("kt_errors: %s invoked on a nil Fault - see `SetPanicOnNilFault()`", method))
+	}
+	return true
+}
+
 var (
 	_EMPTY_NATURAL_FORM = naturalFormFault{
 		Kind:       "NaN",
@@ -566,8 +1769,153 @@ var (
 )
 
 func (fault *defaultFault) ToNaturalJSON(forAudience string, options ...SerializationOption) ([]byte, error) {
+	if fault == noFault {
+		return []byte{}, nil
+	}
+	return marshalNaturalJSON(fault.naturalFormForSerialization(forAudience, options...), options...)
+}
+
+func (fault *defaultFault) ToWarningJSON(forAudience string, options ...SerializationOption) ([]byte, error) {
+	if fault == noFault {
+		return []byte{}, nil
+	}
+	warning := warningFormFault{
+		naturalFormFault: fault.toNaturalForm(forAudience, options...),
+		Severity:         fault.GetSeverity(),
+	}
+	return marshalNaturalJSON(warning, options...)
+}
+
+func (fault *defaultFault) WriteNaturalJSON(w io.Writer, forAudience string, options ...SerializationOption) error {
+	if fault == noFault {
+		return nil
+	}
+	natural := fault.naturalFormForSerialization(forAudience, options...)
+	if getNaturalJSONKeyRenames() == nil {
+		return encodeJSON(w, natural, options...)
+	}
+	data, err := marshalNaturalJSON(natural, options...)
+	if err != nil {
+		return err
+	}
+	// just like `json.Encoder` does
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// Streams the value as JSON into the writer - considering the `PrettyPrint` option.
+func encodeJSON(w io.Writer, value any, options ...SerializationOption) error {
+	encoder := json.NewEncoder(w)
+	if slices.Contains(options, PrettyPrint) {
+		encoder.SetIndent("", "\t")
+	}
+	return encoder.Encode(value)
+}
+
+// Marshals a natural form value - considering the `PrettyPrint` option and the overridden keys (see `SetNaturalJSONFieldNames()`).
+func marshalNaturalJSON(value any, options ...SerializationOption) ([]byte, error) {
+	renames := getNaturalJSONKeyRenames()
+	if renames == nil {
+		if slices.Contains(options, PrettyPrint) {
+			return json.MarshalIndent(value, "", "\t")
+		}
+		return json.Marshal(value)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = renameJSONObjectKeys(data, renames); err != nil {
+		return nil, err
+	}
+	if slices.Contains(options, PrettyPrint) {
+		var indented bytes.Buffer
+		if err = json.Indent(&indented, data, "", "\t"); err != nil {
+			return nil, err
+		}
+		return indented.Bytes(), nil
+	}
+	return data, nil
+}
+
+// Renames the top level keys of the given JSON object - keeping their order. Nested objects are untouched.
+func renameJSONObjectKeys(data []byte, renames map[string]string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		// not an object - nothing to rename
+		return data, nil
+	}
+	var renamed bytes.Buffer
+	renamed.WriteByte('{')
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		key := token.(string)
+		if newKey, found := renames[key]; found {
+			key = newKey
+		}
+		keyJson, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		if renamed.Len() > 1 {
+			renamed.WriteByte(',')
+		}
+		renamed.Write(keyJson)
+		renamed.WriteByte(':')
+		renamed.Write(value)
+	}
+	renamed.WriteByte('}')
+	return renamed.Bytes(), nil
+}
+
+// Returns the value which is serialized in the natural form - considering the `StructuredErrorCodes` option.
+func (fault *defaultFault) naturalFormForSerialization(forAudience string, options ...SerializationOption) any {
+	natural := fault.toNaturalForm(forAudience, options...)
+	if fault != nil && slices.Contains(options, IncludeCause) && slices.Contains(options, AllowNonPublicSerialization) {
+		natural.Cause = causeForSerialization(fault.GetCause(), forAudience, options...)
+	}
+	if slices.Contains(options, StructuredErrorCodes) {
+		return structuredNaturalFormFault{
+			naturalFormFault: natural,
+			ErrorCodes:       fault.structuredErrorCodesForSerialization(options...),
+		}
+	}
+	return natural
+}
+
+// Returns the serializable form of the cause for the `IncludeCause` option - Faults in their natural form (recursively), other errors as error string.
+func causeForSerialization(cause error, forAudience string, options ...SerializationOption) any {
+	if cause == nil {
+		return nil
+	}
+	isFault, causeFault := IsFault(cause)
+	if isFault {
+		if causeJson, err := causeFault.ToNaturalJSON(forAudience, options...); err == nil {
+			return json.RawMessage(causeJson)
+		}
+	}
+	return cause.Error()
+}
+
+// Returns the structured error codes which can be serialized - considering the public guard.
+func (fault *defaultFault) structuredErrorCodesForSerialization(options ...SerializationOption) []ErrorCode {
+	if fault.isNil() || (!fault.IsPublic() && !slices.Contains(options, AllowNonPublicSerialization)) {
+		return make([]ErrorCode, 0)
+	}
+	return fault.GetStructuredErrorCodes()
+}
+
+// Assembles the natural form of the Fault - considering the public guard.
+func (fault *defaultFault) toNaturalForm(forAudience string, options ...SerializationOption) naturalFormFault {
 	var natural naturalFormFault
-	if fault == nil {
+	if fault.isNil() {
 		natural = _EMPTY_NATURAL_FORM
 	} else if !fault.IsPublic() && !slices.Contains(options, AllowNonPublicSerialization) {
 		natural = _NONPUBLIC_NATURAL_FORM
@@ -578,6 +1926,8 @@ func (fault *defaultFault) ToNaturalJSON(forAudience string, options ...Serializ
 			Kind:       fault.Kind,
 			Retryable:  fault.Retryable,
 			ErrorCodes: fault.ErrorCodes,
+			Reference:  fault.Reference,
+			HelpUrl:    fault.HelpUrl,
 		}
 		if natural.ErrorCodes == nil {
 			natural.ErrorCodes = make([]string, 0)
@@ -622,22 +1972,105 @@ func (fault *defaultFault) ToNaturalJSON(forAudience string, options ...Serializ
 				delete(natural.Labels, k)
 			}
 		}
+		natural.Labels = labelsForSerialization(natural.Labels)
 	}
+	return natural
+}
 
+// Returns the labels in the form they should be serialized - `time.Time` values are rendered as RFC3339 strings. If there is nothing to convert then
+// the given map is returned as it is - otherwise a copy.
+func labelsForSerialization(labels map[string]any) map[string]any {
+	hasTime := false
+	for _, value := range labels {
+		if _, isTime := value.(time.Time); isTime {
+			hasTime = true
+			break
+		}
+	}
+	if !hasTime {
+		return labels
+	}
+	converted := make(map[string]any, len(labels))
+	for key, value := range labels {
+		if t, isTime := value.(time.Time); isTime {
+			value = t.Format(time.RFC3339)
+		}
+		converted[key] = value
+	}
+	return converted
+}
+
+func (fault *defaultFault) ToFullJSON(options ...SerializationOption) ([]byte, error) {
+	if fault == noFault {
+		return []byte{}, nil
+	}
+	full := fault.fullFormForSerialization(options...)
 	if slices.Contains(options, PrettyPrint) {
-		return json.MarshalIndent(natural, "", "\t")
+		return json.MarshalIndent(full, "", "\t")
 	} else {
-		return json.Marshal(natural)
+		return json.Marshal(full)
 	}
 }
 
-func (fault *defaultFault) ToFullJSON(options ...SerializationOption) ([]byte, error) {
+func (fault *defaultFault) ToI18NJSON(options ...SerializationOption) ([]byte, error) {
+	if fault == noFault {
+		return []byte{}, nil
+	}
+	i18n := fault.toI18nForm(options...)
+	if slices.Contains(options, PrettyPrint) {
+		return json.MarshalIndent(i18n, "", "\t")
+	} else {
+		return json.Marshal(i18n)
+	}
+}
+
+// Returns the i18n form of the Fault - considering the public guard and the `OnlyAudiences()` option.
+func (fault *defaultFault) toI18nForm(options ...SerializationOption) i18nFormFault {
+	i18n := i18nFormFault{
+		Kind:               _NONPUBLIC_NATURAL_FORM.Kind,
+		MessagesByAudience: make(map[string]string),
+		Labels:             make(map[string]any),
+		ErrorCodes:         make([]string, 0),
+	}
+	if fault.isNil() {
+		i18n.Kind = _EMPTY_NATURAL_FORM.Kind
+		return i18n
+	}
+	if !fault.IsPublic() && !slices.Contains(options, AllowNonPublicSerialization) {
+		return i18n
+	}
+	i18n.Kind = fault.Kind
+	i18n.MessageTemplate = fault.MessageTemplate
+	onlyAudiences, filterAudiences := onlyAudiencesOf(options)
+	for audience, template := range fault.MessageTemplatesByAudience {
+		if !filterAudiences || onlyAudiences.Contains(audience) {
+			i18n.MessagesByAudience[audience] = template
+		}
+	}
+	if fault.Labels != nil {
+		i18n.Labels = labelsForSerialization(fault.Labels)
+	}
+	if fault.ErrorCodes != nil {
+		i18n.ErrorCodes = fault.ErrorCodes
+	}
+	return i18n
+}
+
+func (fault *defaultFault) WriteFullJSON(w io.Writer, options ...SerializationOption) error {
+	if fault == noFault {
+		return nil
+	}
+	return encodeJSON(w, fault.fullFormForSerialization(options...), options...)
+}
+
+// Returns the value which is serialized in the full form - considering the public guard and all the options.
+func (fault *defaultFault) fullFormForSerialization(options ...SerializationOption) any {
 
 	resolveMessages := slices.Contains(options, ResolveMessages)
 	leaveVars := slices.Contains(options, LeaveMessageVarsInLabels)
 
 	var _fault defaultFault
-	if fault == nil {
+	if fault.isNil() {
 		_fault = _EMPTY_FAULT
 	} else if !fault.IsPublic() && !slices.Contains(options, AllowNonPublicSerialization) {
 		_fault = _NONPUBLIC_FAULT
@@ -651,6 +2084,13 @@ func (fault *defaultFault) ToFullJSON(options ...SerializationOption) ([]byte, e
 		}
 	}
 
+	onlyAudiences, filterAudiences := onlyAudiencesOf(options)
+	if filterAudiences && !resolveMessages && _fault.MessageTemplatesByAudience != nil {
+		// we need to work on a copy before we alter it - to avoid changing original
+		_fault.MessageTemplatesByAudience = maps.Clone(_fault.MessageTemplatesByAudience)
+		maps.DeleteFunc(_fault.MessageTemplatesByAudience, func(k string, _ string) bool { return !onlyAudiences.Contains(k) })
+	}
+
 	if resolveMessages {
 		var msgVars ktsets.Set[string]
 		_fault.MessageTemplate = fault.GetMessage()
@@ -660,7 +2100,9 @@ func (fault *defaultFault) ToFullJSON(options ...SerializationOption) ([]byte, e
 		// we need to work on a copy before we alter it - to avoid changing original
 		_fault.MessageTemplatesByAudience = make(map[string]string, len(fault.MessageTemplatesByAudience))
 		for k := range fault.MessageTemplatesByAudience {
-			_fault.MessageTemplatesByAudience[k] = fault.GetMessageForAudience(k)
+			if !filterAudiences || onlyAudiences.Contains(k) {
+				_fault.MessageTemplatesByAudience[k] = fault.GetMessageForAudience(k)
+			}
 			if !leaveVars {
 				msgVars.Union(kt_utils.StringExtractVariableNames(fault.MessageTemplatesByAudience[k]))
 			}
@@ -673,23 +2115,176 @@ func (fault *defaultFault) ToFullJSON(options ...SerializationOption) ([]byte, e
 		}
 	}
 
-	if slices.Contains(options, PrettyPrint) {
-		return json.MarshalIndent(_fault, "", "\t")
-	} else {
-		return json.Marshal(_fault)
+	_fault.Labels = labelsForSerialization(_fault.Labels)
+
+	full := versionedFullFormFault{
+		SchemaVersion: FULLJSON_SCHEMA_VERSION,
+		fullFormFault: fullFormFault(_fault),
+	}
+	if slices.Contains(options, StructuredErrorCodes) {
+		return structuredFullFormFault{
+			versionedFullFormFault: full,
+			ErrorCodes:             fault.structuredErrorCodesForSerialization(options...),
+		}
+	}
+	return full
+}
+
+// This is used only for the full JSON serialization - this type does not have the `MarshalJSON()` method of `defaultFault` so the exported fields are serialized
+type fullFormFault defaultFault
+
+// The full form extended with the schema version - this is what is serialized
+type versionedFullFormFault struct {
+	SchemaVersion string `json:"schemaVersion" yaml:"schemaVersion"`
+	fullFormFault
+}
+
+// The full form but with structured error codes - see `StructuredErrorCodes` option
+type structuredFullFormFault struct {
+	versionedFullFormFault
+	ErrorCodes []ErrorCode `json:"errorCodes" yaml:"errorCodes"`
+}
+
+// This is used only for the i18n JSON serialization - see `ToI18NJSON()`
+type i18nFormFault struct {
+	Kind               FaultKind         `json:"kind" yaml:"kind"`
+	MessageTemplate    string            `json:"messageTemplate" yaml:"messageTemplate"`
+	MessagesByAudience map[string]string `json:"messagesByAudience" yaml:"messagesByAudience"`
+	Labels             map[string]any    `json:"labels" yaml:"labels"`
+	ErrorCodes         []string          `json:"errorCodes" yaml:"errorCodes"`
+}
+
+// The natural form extended with the severity - see `ToWarningJSON()`
+type warningFormFault struct {
+	naturalFormFault
+	Severity Severity `json:"severity" yaml:"severity"`
+}
+
+// The natural form but with structured error codes - see `StructuredErrorCodes` option
+type structuredNaturalFormFault struct {
+	naturalFormFault
+	ErrorCodes []ErrorCode `json:"errorCodes" yaml:"errorCodes"`
+}
+
+// Implementation of the `json.Marshaler` iface - so a Fault embedded into any bigger struct is serialized safe way by default. This is equivalent to
+// `ToNaturalJSON("")` - including the defense mechanism against non-public Faults (they are serialized in the blank form).
+// If you want the richer form you must use `ToFullJSON()` explicitly.
+func (fault *defaultFault) MarshalJSON() ([]byte, error) {
+	if fault == noFault {
+		return []byte("null"), nil
 	}
+	return fault.ToNaturalJSON("")
+}
+
+// A concrete type meant to be used as a field of bigger structs (e.g. DTOs) - making Faults first-class JSON citizens there. It marshals to the natural
+// form (just like `MarshalJSON()` of any Fault - so non-public Faults are still serialized in the blank form) and `json.Unmarshal()` rehydrates it as a
+// public Fault (with a new instance id). An empty field (no Fault) is serialized as `null` and vice versa.
+//
+// Wrap a Fault with `PublicFaultOf()` and get it back with `GetFault()`. The type is intentionally not a Fault (nor an error) itself - so an empty field
+// can not be mistaken for a Fault.
+//
+// Please note: the natural form does not carry everything (e.g. audience messages, cause, call stack) - so these are not restored.
+type PublicFault struct {
+	fault Fault
+}
+
+// Wraps the given Fault into a `PublicFault` - see there. Nil (or `NoFault`) gives an empty `PublicFault`.
+func PublicFaultOf(fault Fault) PublicFault {
+	if IsNoFault(fault) {
+		return PublicFault{}
+	}
+	return PublicFault{fault: fault}
+}
+
+// Returns the wrapped Fault - or nil if there is none.
+func (pf PublicFault) GetFault() Fault {
+	return pf.fault
+}
+
+// Implementation of the `json.Marshaler` iface - see `PublicFault`.
+func (pf PublicFault) MarshalJSON() ([]byte, error) {
+	if pf.fault == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(pf.fault)
+}
+
+// Implementation of the `json.Unmarshaler` iface - see `PublicFault`.
+//
+// Returns a non-public `ValidationFault` with `VALIDATION_ERRCODE_INVALID_VALUE` error code if the kind is not a registered one (see
+// `RegisterFaultKinds()`).
+func (pf *PublicFault) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		pf.fault = nil
+		return nil
+	}
+	if renames := getNaturalJSONKeyRenames(); renames != nil {
+		// map the overridden keys back to the default ones
+		defaultKeys := make(map[string]string, len(renames))
+		for defaultKey, overriddenKey := range renames {
+			defaultKeys[overriddenKey] = defaultKey
+		}
+		var err error
+		if data, err = renameJSONObjectKeys(data, defaultKeys); err != nil {
+			return err
+		}
+	}
+	var natural naturalFormFault
+	if err := json.Unmarshal(data, &natural); err != nil {
+		return err
+	}
+	if !IsRegisteredFaultKind(natural.Kind) {
+		return NewFaultBuilder(ValidationFault).
+			WithMessageTemplate("Fault has unknown kind '{kind}' - see `RegisterFaultKinds()`").
+			WithErrorCode(VALIDATION_ERRCODE_INVALID_VALUE).
+			WithLabel("kind", natural.Kind).
+			Build()
+	}
+	builder := NewPublicFaultBuilder(natural.Kind)
+	// the Fault was built (and counted) where it was serialized
+	builder.uncounted = true
+	pf.fault = builder.
+		WithMessageTemplate(natural.Message).
+		WithIsRetryable(natural.Retryable).
+		WithErrorCodes(natural.ErrorCodes...).
+		WithLabels(natural.Labels).
+		WithReference(natural.Reference).
+		WithHelpUrl(natural.HelpUrl).
+		Build()
+	return nil
+}
+
+// This is used only for the CloudEvents data payload
+type cloudEventDataFault struct {
+	Type string `json:"type"`
+	naturalFormFault
+}
+
+func (fault *defaultFault) ToCloudEventData() ([]byte, error) {
+	if fault == noFault {
+		return []byte{}, nil
+	}
+	natural := fault.toNaturalForm("", ResolveMessages)
+	return json.Marshal(cloudEventDataFault{
+		Type:             cloudEventTypePrefix + natural.Kind,
+		naturalFormFault: natural,
+	})
 }
 
 // The implementation of Error iface - this considers if the error is public or not.
 // If not public then just prints the resolved message and safe info (to avoid leaking internal info) - otherwise also reveals labels
 func (fault *defaultFault) Error() string {
-	codesStr := "[]"
-	if len(fault.ErrorCodes) > 0 {
-		codesStr = fmt.Sprintf("['%s']", strings.Join(fault.ErrorCodes, "','"))
+	if fault == noFault {
+		return "no fault"
 	}
+	codesStr := fault.GetErrorCodesString()
 	if fault.public {
+		labStr := kt_utils.PrintVarS(fault.Labels, false)
+		if len(fault.Labels) > 0 {
+			labStr = printSortedMap(fault.Labels)
+		}
 		return fmt.Sprintf("%s: %s (retryable: %t, errorCodes: %s, labels: %s)",
-			fault.Kind, fault.GetMessage(), fault.Retryable, codesStr, kt_utils.PrintVarS(fault.Labels, false))
+			fault.Kind, fault.GetMessage(), fault.Retryable, codesStr, labStr)
 	} else {
 		return fmt.Sprintf("%s: %s (retryable: %t, errorCodes: %s)",
 			fault.Kind, fault.GetMessage(), fault.Retryable, codesStr)
@@ -698,45 +2293,150 @@ func (fault *defaultFault) Error() string {
 
 // The fmt.Stringer implementation which is producing complete string representation of the error. Useful for logging purposes.
 func (fault *defaultFault) String() string {
+	if fault == noFault {
+		return "NoFault"
+	}
 	causeStr := "nil"
-	if fault.cause != nil {
-		isKtErr, ktErr := IsFault(fault.cause)
-		if isKtErr {
-			// we use the to string mechanism
-			causeStr = fmt.Sprintf("{%s}", ktErr.String())
-		} else {
-			// we print it normal way
-			causeStr = fmt.Sprintf("'%s'", fault.cause)
+	if len(fault.causes) == 1 {
+		causeStr = causeToString(fault.causes[0])
+	} else if len(fault.causes) > 1 {
+		causeStrs := make([]string, len(fault.causes))
+		for i, cause := range fault.causes {
+			causeStrs[i] = causeToString(cause)
 		}
-
+		causeStr = fmt.Sprintf("[%s]", strings.Join(causeStrs, ", "))
 	}
-	codesStr := "[]"
-	if len(fault.ErrorCodes) > 0 {
-		codesStr = fmt.Sprintf("['%s']", strings.Join(fault.ErrorCodes, "','"))
+	fields := fault.stringFields()
+	fields = insertStringFieldAfter(fields, "callStack", faultStringField{"cause", causeStr})
+
+	fieldStrs := make([]string, len(fields))
+	for i, field := range fields {
+		fieldStrs[i] = field.name + ": " + field.value
 	}
+	return "Fault{" + strings.Join(fieldStrs, ", ") + "}"
+}
+
+// One field of the `String()` / `StringIndented()` representation
+type faultStringField struct {
+	name  string
+	value string
+}
+
+// Inserts the field right after the field with the given name
+func insertStringFieldAfter(fields []faultStringField, afterName string, field faultStringField) []faultStringField {
+	idx := slices.IndexFunc(fields, func(f faultStringField) bool { return f.name == afterName })
+	return slices.Insert(fields, idx+1, field)
+}
+
+// Renders the fields shared by `String()` and `StringIndented()` - in the order they appear, without the cause (as that is rendered differently).
+func (fault *defaultFault) stringFields() []faultStringField {
 	callStackStr := "[]"
 	if len(fault.callStack) > 0 {
 		callStackStr = fmt.Sprintf("['%s']", strings.Join(fault.GetCallStack(), "','"))
 	}
 	audMsgsStr := "{}"
 	if len(fault.MessageTemplatesByAudience) > 0 {
-		audMsgsStr = kt_utils.PrintVarS(fault.MessageTemplatesByAudience, false)
+		audMsgsStr = printSortedMap(fault.MessageTemplatesByAudience)
 	}
 	labStr := "{}"
 	if len(fault.Labels) > 0 {
-		labStr = kt_utils.PrintVarS(fault.Labels, false)
-	}
-
-	return fmt.Sprintf(
-		"Fault{type: '%s', msgTemplate: '%s', retryable: %t, public: %t, codes: %s, callStack: %s, cause: %s, audienceMsgs: %s, labels: %s}",
-		fault.Kind,
-		fault.MessageTemplate,
-		fault.Retryable,
-		fault.public,
-		codesStr,
-		callStackStr,
-		causeStr,
-		audMsgsStr,
-		labStr,
-	)
+		labStr = printSortedMap(fault.Labels)
+	}
+	retryableStr := fmt.Sprintf("%t", fault.Retryable)
+	if fault.retryabilityDenied {
+		retryableStr = fmt.Sprintf("%t (denied: %s)", fault.Retryable, fault.retryabilityReason)
+	}
+
+	return []faultStringField{
+		{"type", fmt.Sprintf("'%s'", fault.Kind)},
+		{"instanceId", fmt.Sprintf("'%s'", fault.InstanceId)},
+		{"msgTemplate", fmt.Sprintf("'%s'", fault.MessageTemplate)},
+		{"retryable", retryableStr},
+		{"public", fmt.Sprintf("%t", fault.public)},
+		{"codes", fault.GetErrorCodesString()},
+		{"callStack", callStackStr},
+		{"audienceMsgs", audMsgsStr},
+		{"labels", labStr},
+	}
+}
+
+func (fault *defaultFault) LogSelf(level kt_logging.LogLevel) {
+	if fault.isNoFault() {
+		return
+	}
+	logger := fault.logger
+	if logger == nil {
+		logger = getDefaultLogger()
+	}
+	logger.Log(level, "%s", kt_utils.VarPrinter{TheVar: fault})
+}
+
+// The multi-line counterpart of `String()` - see `StringIndented()` in the `Fault` interface.
+func (fault *defaultFault) StringIndented() string {
+	if fault == noFault {
+		return "NoFault"
+	}
+	fields := fault.stringFields()
+	if fault.Reference != "" {
+		fields = insertStringFieldAfter(fields, "public", faultStringField{"reference", fmt.Sprintf("'%s'", fault.Reference)})
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("Fault{\n")
+	for _, field := range fields {
+		fmt.Fprintf(&sb, "%s%s: %s\n", indentedStringIndent, field.name, field.value)
+	}
+	if len(fault.causes) == 0 {
+		fmt.Fprintf(&sb, "%scause: nil\n", indentedStringIndent)
+	} else {
+		fmt.Fprintf(&sb, "%scause:\n", indentedStringIndent)
+		for _, cause := range fault.causes {
+			sb.WriteString(indentLines(causeToIndentedString(cause), indentedStringIndent+indentedStringIndent))
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// One level of indentation in `StringIndented()`
+const indentedStringIndent = "  "
+
+// Renders a cause for `StringIndented()` method
+func causeToIndentedString(cause error) string {
+	isKtErr, ktErr := IsFault(cause)
+	if isKtErr {
+		return ktErr.StringIndented()
+	}
+	return fmt.Sprintf("'%s'", cause)
+}
+
+// Prefixes all lines of the given (possibly multi-line) string with the indent
+func indentLines(str string, indent string) string {
+	lines := strings.Split(str, "\n")
+	for i, line := range lines {
+		lines[i] = indent + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Renders the map for `Error()` / `String()` methods - in the same form `kt_utils.PrintVarS()` does but the keys are explicitly sorted so the
+// output is always deterministic.
+func printSortedMap[V any](m map[string]V) string {
+	entries := make([]string, 0, len(m))
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		entries = append(entries, fmt.Sprintf("%q:%s", key, kt_utils.PrintVarS(m[key], false)))
+	}
+	return fmt.Sprintf("%s{%s}", strings.ReplaceAll(fmt.Sprintf("%T", m), " ", ""), strings.Join(entries, ","))
+}
+
+// Renders a cause for `String()` method
+func causeToString(cause error) string {
+	isKtErr, ktErr := IsFault(cause)
+	if isKtErr {
+		// we use the to string mechanism
+		return fmt.Sprintf("{%s}", ktErr.String())
+	}
+	// we print it normal way
+	return fmt.Sprintf("'%s'", cause)
 }
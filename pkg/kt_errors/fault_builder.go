@@ -1,9 +1,20 @@
 package kt_errors
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/keytiles/lib-logging-golang/v2/pkg/kt_logging"
 	"github.com/keytiles/lib-sets-golang/ktsets"
 )
 
@@ -12,7 +23,7 @@ import (
 func NewPublicFaultBuilder(errType FaultKind) *FaultBuilder {
 	err := newInitializedFault(errType)
 	err.public = true
-	return &FaultBuilder{fault: err, errCodes: ktsets.NewSet[string]()}
+	return &FaultBuilder{fault: err, errCodes: ktsets.NewSet[string](), tags: ktsets.NewSet[string]()}
 }
 
 // Creates a new FaultBuilder marked "non public" and you can convenient way fine tune the error before you invoke `Build()` method on it.
@@ -20,59 +31,251 @@ func NewPublicFaultBuilder(errType FaultKind) *FaultBuilder {
 func NewFaultBuilder(errType FaultKind) *FaultBuilder {
 	err := newInitializedFault(errType)
 	err.public = false
-	return &FaultBuilder{fault: err, errCodes: ktsets.NewSet[string]()}
+	return &FaultBuilder{fault: err, errCodes: ktsets.NewSet[string](), tags: ktsets.NewSet[string]()}
+}
+
+// A reusable definition of a known error - e.g. an entry of a centralized error catalog loaded from config. See `NewFaultBuilderFromTemplate()`.
+type FaultTemplate struct {
+	Kind FaultKind `json:"kind" yaml:"kind"`
+	// If true the builder is created with `NewPublicFaultBuilder()`
+	Public             bool              `json:"public" yaml:"public"`
+	MessageTemplate    string            `json:"message" yaml:"message"`
+	MessagesByAudience map[string]string `json:"messagesByAudience,omitempty" yaml:"messagesByAudience,omitempty"`
+	ErrorCodes         []string          `json:"errorCodes,omitempty" yaml:"errorCodes,omitempty"`
+	// If nil the retryability is left to the defaults (see `WithIsRetryable()`)
+	Retryable *bool `json:"isRetryable,omitempty" yaml:"isRetryable,omitempty"`
+}
+
+// Creates a new FaultBuilder seeded from the given template - so callers only need to fill in the runtime data (typically the labels) before `Build()`.
+// The builder gets its own copy of everything, so the template is never modified and can be reused for any number of Faults.
+func NewFaultBuilderFromTemplate(template FaultTemplate) *FaultBuilder {
+	builder := NewFaultBuilder(template.Kind).
+		WithPublic(template.Public).
+		WithMessageTemplate(template.MessageTemplate).
+		WithErrorCodes(template.ErrorCodes...)
+	for _, audience := range slices.Sorted(maps.Keys(template.MessagesByAudience)) {
+		builder.WithMessageTemplateForAudience(audience, template.MessagesByAudience[audience])
+	}
+	if template.Retryable != nil {
+		builder.WithIsRetryable(*template.Retryable)
+	}
+	return builder
 }
 
 type FaultBuilder struct {
 	fault    defaultFault
 	errCodes ktsets.Set[string]
+	tags     ktsets.Set[string]
+	// true if the retryable flag was explicitly set with `WithIsRetryable()` - and the flag which was requested there
+	retryableSet       bool
+	retryableRequested bool
+	// true once `Build()` was invoked - further builds need their own instance id
+	built bool
+	// true if the Faults are taken from the pool - see `NewPooledFaultBuilder()`
+	pooled bool
+	// true if the library builds a Fault from already existing error(s) - e.g. the public conversion - these are not counted in `FaultStats()`
+	uncounted bool
 }
 
 func (builder *FaultBuilder) Build() Fault {
 	_fault := builder.fault
 
-	// labels remain mutable - so we need a copy there from the builder
-	if builder.fault.Labels != nil {
+	// every built Fault is a separate instance
+	if builder.built {
+		_fault.InstanceId = generateInstanceId()
+	}
+	builder.built = true
+
+	// labels remain mutable - so we need a copy there from the builder (pooled Faults copy them into their recycled map)
+	if builder.fault.Labels != nil && !builder.pooled {
 		_fault.Labels = builder.fault.GetLabels()
 	}
 
+	// the audience messages, the public label keys and causes too
+	_fault.MessageTemplatesByAudience = maps.Clone(builder.fault.MessageTemplatesByAudience)
+	_fault.publicLabelKeys = slices.Clone(builder.fault.publicLabelKeys)
+	_fault.causes = slices.Clone(builder.fault.causes)
+
 	// assemble error codes
 	if builder.errCodes.Size() > 0 {
 		_fault.ErrorCodes = builder.errCodes.GetAll()
 	}
+	_fault.errorCodeCategories = maps.Clone(builder.fault.errorCodeCategories)
+
+	// assemble tags - sorted
+	if builder.tags.Size() > 0 {
+		_fault.Tags = builder.tags.GetAll()
+		slices.Sort(_fault.Tags)
+	}
+
+	// we keep track why the error is (not) retryable - see `RetryabilityReason()`
+	_fault.retryabilityReason = "not marked retryable"
+	if builder.retryableSet {
+		_fault.retryabilityReason = fmt.Sprintf("explicitly set retryable=%t", builder.retryableRequested)
+	}
+	if _fault.retryableForced {
+		_fault.retryabilityReason = fmt.Sprintf("forced retryable=%t - the kind rules are bypassed", builder.retryableRequested)
+	}
+
+	// timeouts / cancellations in the causes classify the error - unless the caller decided explicitly
+	// a cancellation is not retryable though: the caller gave up, repeating the call would not help
+	if code := timeoutErrorCodeOf(_fault.causes); code != "" {
+		if builder.errCodes.Size() == 0 {
+			_fault.ErrorCodes = append(_fault.ErrorCodes, code)
+		}
+		if code == ILLEGALSTATE_ERRCODE_TIMED_OUT && !builder.retryableSet {
+			_fault.Retryable = true
+			_fault.retryabilityReason = "cause is a timeout"
+		}
+	}
+
+	// rate limiting is retryable by nature - unless the caller decided explicitly
+	if _fault.Kind == IllegalStateFault && _fault.HasErrorCode(ILLEGALSTATE_ERRCODE_RATE_LIMITED) && !builder.retryableSet {
+		_fault.Retryable = true
+		_fault.retryabilityReason = "rate limited"
+	}
+
+	// public errors always get a reference
+	if _fault.public && _fault.Reference == "" {
+		_fault.Reference = generateReference()
+	}
 
 	// review the isRetryable flag
-	if builder.fault.Retryable {
-		switch builder.fault.Kind {
-		case AuthenticationFault:
-			if builder.errCodes.ContainsAny(AUTHENTICATION_ERRCODE_MISSING, AUTHENTICATION_ERRCODE_NOT_SUPPORTED) {
-				builder.fault.Retryable = false
-			}
-		case AuthorizationFault:
-			if builder.errCodes.ContainsAny(AUTHORIZATION_NO_PERMISSION) {
-				builder.fault.Retryable = false
-			}
+	_fault.applyRetryabilityRules()
+	// `WithIsRetryable(true)` might have been ignored already because of the kind - then we explain that too
+	if builder.retryableSet && builder.retryableRequested && !_fault.Retryable && !_fault.retryabilityDenied {
+		if reason := _fault.retryabilityDeniedReason(); reason != "" {
+			_fault.denyRetryability(reason)
 		}
 	}
 
+	// the message is resolved right away - see `refreshResolvedMessage()`
+	_fault.hasResolvedMessage = true
+	_fault.refreshResolvedMessage()
+
+	if !builder.uncounted {
+		countBuiltFault(_fault.Kind)
+	}
+
+	if builder.pooled {
+		return takePooledFault(_fault)
+	}
 	return &_fault
 }
 
+// Returns a human-readable dump of what the builder currently holds (kind, message templates, codes, labels, public and retryable flags) - without
+// invoking `Build()` and without changing anything in the builder. Useful for debugging complex conditional builder chains.
+//
+// Please note: the flags are shown as they are set so far - the rules `Build()` applies (e.g. retryability rules) are not evaluated here.
+func (builder *FaultBuilder) Snapshot() string {
+	codesStr := "[]"
+	if builder.errCodes.Size() > 0 {
+		codesStr = fmt.Sprintf("['%s']", strings.Join(slices.Sorted(slices.Values(builder.errCodes.GetAll())), "','"))
+	}
+	audMsgsStr := "{}"
+	if len(builder.fault.MessageTemplatesByAudience) > 0 {
+		audMsgsStr = printSortedMap(builder.fault.MessageTemplatesByAudience)
+	}
+	labStr := "{}"
+	if len(builder.fault.Labels) > 0 {
+		labStr = printSortedMap(builder.fault.Labels)
+	}
+	return fmt.Sprintf(
+		"FaultBuilder{type: '%s', msgTemplate: '%s', retryable: %t, public: %t, codes: %s, audienceMsgs: %s, labels: %s}",
+		builder.fault.Kind,
+		builder.fault.MessageTemplate,
+		builder.fault.Retryable,
+		builder.fault.public,
+		codesStr,
+		audMsgsStr,
+		labStr,
+	)
+}
+
+// Same as `Build()` but before building it also validates the Fault and returns error (and no Fault) if the validation fails. The returned error is a
+// non-public `IllegalStateFault` with `ILLEGALSTATE_ERRCODE_CODE_BUG` error code - as this is clearly a mistake in the code.
+//
+// Validations:
+//   - the kind must be a known kind - built-in or registered with `RegisterFaultKinds()`
+//   - in strict audiences mode (see `SetStrictAudiences()`) all audiences of the message templates must be registered (see `RegisterAudience()`)
+func (builder *FaultBuilder) BuildStrict() (Fault, error) {
+	if !IsRegisteredFaultKind(builder.fault.Kind) {
+		return nil, newStrictBuildFault("Fault has unknown kind {kind} - see `RegisterFaultKinds()`").
+			WithLabel("kind", builder.fault.Kind).
+			Build()
+	}
+	if IsStrictAudiences() {
+		unknownAudiences := make([]string, 0)
+		for _, audience := range slices.Sorted(maps.Keys(builder.fault.MessageTemplatesByAudience)) {
+			if !IsRegisteredAudience(audience) {
+				unknownAudiences = append(unknownAudiences, audience)
+			}
+		}
+		if len(unknownAudiences) > 0 {
+			return nil, newStrictBuildFault("Fault has message templates for unregistered audiences {audiences} - see `RegisterAudience()`").
+				WithLabel("audiences", unknownAudiences).
+				Build()
+		}
+	}
+	return builder.Build(), nil
+}
+
+// Returns a builder of the error `BuildStrict()` returns if the validation fails.
+func newStrictBuildFault(msgTemplate string) *FaultBuilder {
+	return NewFaultBuilder(IllegalStateFault).
+		WithMessageTemplate(msgTemplate).
+		WithErrorCode(ILLEGALSTATE_ERRCODE_CODE_BUG)
+}
+
+// Sets if this error is public or not - overriding what you decided when you created the builder with `NewFaultBuilder()` or `NewPublicFaultBuilder()`.
+// This way a single construction path can decide this based on runtime conditions (e.g. a config deciding whether to expose details in dev).
+// Please note: once the error is built, this flag can not be changed anymore.
+func (builder *FaultBuilder) WithPublic(flag bool) *FaultBuilder {
+	builder.fault.public = flag
+	return builder
+}
+
+// Sets the kind of this error - overriding what you decided when you created the builder with `NewFaultBuilder()` or `NewPublicFaultBuilder()`.
+// This way a single generic construction path can choose the kind later (e.g. based on a parameter). The public flag and all other data are preserved.
+//
+// Please note: retryability rules of the new kind (see `SetKindRetryabilityPolicy()`) are not re-applied retroactively here - that happens in `Build()`.
+// And the kind is not validated here either - use `BuildStrict()` if you want to make sure it is a known kind (see `RegisterFaultKinds()`).
+func (builder *FaultBuilder) WithKind(kind FaultKind) *FaultBuilder {
+	builder.fault.Kind = kind
+	return builder
+}
+
 // Sets if this error is retryable or not.
 //
 // Please note: certain error types are inheritedly not retryable, e.g. ValidationError or NotImplementedError. Invoking this method
-// on any of those will simply have no effect.
+// on any of those will simply have no effect. (See `SetKindRetryabilityPolicy()` if you need to adjust this - or `WithForcedRetryable()` for one
+// single error.)
+//
+// If you do not invoke this method but a cause of the error is a timeout (see `WithCause()`) then the error becomes retryable automatically.
 func (builder *FaultBuilder) WithIsRetryable(flag bool) *FaultBuilder {
-	switch builder.fault.Kind {
-	// only these types can be classified as retryable
-	case NotImplementedFault, ValidationFault, ResourceNotFoundFault:
-		// we skip it - these are inheritedly not retryable
-	default:
+	builder.retryableSet = true
+	builder.retryableRequested = flag
+	builder.fault.retryableForced = false
+	// inheritedly not retryable kinds are skipped
+	if IsKindRetryabilityAllowed(builder.fault.Kind) {
 		builder.fault.Retryable = flag
 	}
 	return builder
 }
 
+// ESCAPE HATCH! Same as `WithIsRetryable()` but this one bypasses the kind based rules - so e.g. a `ValidationFault` can be retryable too (think of a
+// validation depending on a momentarily stale cache). The flag sticks: neither `Build()` nor `fault.WithKindOverride()` changes it and the
+// `fault.RetryabilityReason()` tells it was forced - so the override remains auditable.
+//
+// Use it only if you really mean it - `WithIsRetryable()` is the safe choice in almost every case.
+func (builder *FaultBuilder) WithForcedRetryable(flag bool) *FaultBuilder {
+	builder.retryableSet = true
+	builder.retryableRequested = flag
+	builder.fault.Retryable = flag
+	builder.fault.retryableForced = true
+	return builder
+}
+
 // Setting the message template of the error.
 // Why is it a "template"? Because you can use variables in it (Python style), e.g. "My string with {var1} and {var2} variables.".
 // Then add these as labels (see `WithLabel()` / `WithLabels()`).
@@ -81,8 +284,28 @@ func (builder *FaultBuilder) WithMessageTemplate(msg string) *FaultBuilder {
 	return builder
 }
 
+// Same as `WithMessageTemplate()` but only applied if `cond` is true - so conditional construction does not break the builder chain.
+func (builder *FaultBuilder) WithMessageTemplateIf(cond bool, msg string) *FaultBuilder {
+	if cond {
+		builder.WithMessageTemplate(msg)
+	}
+	return builder
+}
+
+// Same as `WithMessageTemplate()` but the template is built with `fmt.Sprintf()` right away - so you can mix values known at construction time with
+// "{placeholder}" variables resolved later from labels in one call. It is a convenience over `WithMessageTemplate(fmt.Sprintf(...))`.
+// Please note: a "%" in the result is fine - only the "{placeholder}" variables are resolved later.
+//
+// IMPORTANT! Just like with `WithMessageTemplateForAudienceFmt()` a "{x}" in an arg value becomes a placeholder too and gets resolved from the labels
+// (there is no escaping). Never pass untrusted values (e.g. request data) as args - add them as labels instead.
+func (builder *FaultBuilder) WithMessageTemplatef(format string, args ...any) *FaultBuilder {
+	return builder.WithMessageTemplate(fmt.Sprintf(format, args...))
+}
+
 // Sets a message template for a specific audience.
+// In strict audiences mode (see `SetStrictAudiences()`) a warning is logged if the audience is not registered (see `RegisterAudience()`).
 func (builder *FaultBuilder) WithMessageTemplateForAudience(forAudience string, msg string) *FaultBuilder {
+	warnIfUnregisteredAudiences(forAudience)
 	if builder.fault.MessageTemplatesByAudience == nil {
 		builder.fault.MessageTemplatesByAudience = make(map[string]string)
 	}
@@ -90,6 +313,15 @@ func (builder *FaultBuilder) WithMessageTemplateForAudience(forAudience string,
 	return builder
 }
 
+// Same as `WithMessageTemplateForAudience()` but the template is built with `fmt.Sprintf()` style args baked in right away. Useful for static values
+// known at construction time which should not become labels / placeholders. The "{placeholder}" variables in the result are still resolved from labels.
+//
+// IMPORTANT! The args are baked into the template - so a "{x}" in an arg value becomes a placeholder too and gets resolved from the labels (there is no
+// escaping). Never pass untrusted values (e.g. request data) as args - add them as labels instead.
+func (builder *FaultBuilder) WithMessageTemplateForAudienceFmt(forAudience string, format string, args ...any) *FaultBuilder {
+	return builder.WithMessageTemplateForAudience(forAudience, fmt.Sprintf(format, args...))
+}
+
 // If you changed your mind you can remove the template for this audience
 func (builder *FaultBuilder) WithoutMessageTemplateForAudiences(forAudiences ...string) *FaultBuilder {
 	if builder.fault.MessageTemplatesByAudience == nil {
@@ -105,10 +337,12 @@ func (builder *FaultBuilder) WithoutMessageTemplateForAudiences(forAudiences ...
 }
 
 // Adds all audience message templates to the error - this is a merge.
+// In strict audiences mode (see `SetStrictAudiences()`) a warning is logged if an audience is not registered (see `RegisterAudience()`).
 func (builder *FaultBuilder) WithMessageTemplatesByAudience(templates map[string]string) *FaultBuilder {
 	if len(templates) == 0 {
 		return builder
 	}
+	warnIfUnregisteredAudiences(slices.Sorted(maps.Keys(templates))...)
 	if builder.fault.MessageTemplatesByAudience == nil {
 		builder.fault.MessageTemplatesByAudience = make(map[string]string, len(templates))
 	}
@@ -117,26 +351,98 @@ func (builder *FaultBuilder) WithMessageTemplatesByAudience(templates map[string
 }
 
 // Adds all audience message templates to the error - and these will override the possibly existing ones.
+// In strict audiences mode (see `SetStrictAudiences()`) a warning is logged if an audience is not registered (see `RegisterAudience()`).
 func (builder *FaultBuilder) WithExactMessageTemplatesByAudience(templates map[string]string) *FaultBuilder {
 	if len(templates) == 0 {
 		builder.fault.MessageTemplatesByAudience = nil
 		return builder
 	}
+	warnIfUnregisteredAudiences(slices.Sorted(maps.Keys(templates))...)
 
 	builder.fault.MessageTemplatesByAudience = make(map[string]string, len(templates))
 	maps.Copy(builder.fault.MessageTemplatesByAudience, templates)
 	return builder
 }
 
+// Sets the short, human-quotable reference of the error explicitly. Public errors get a generated one automatically at build time if you do not set it
+// - see `SetReferenceGenerator()`.
+func (builder *FaultBuilder) WithReference(ref string) *FaultBuilder {
+	builder.fault.Reference = ref
+	return builder
+}
+
+// Attaches a documentation / troubleshooting URL to the error - see `fault.GetHelpUrl()`. For public errors the URL is rendered into the JSON forms as
+// "helpUrl" while non-public errors suppress it (just like any other detail).
+func (builder *FaultBuilder) WithHelpUrl(helpUrl string) *FaultBuilder {
+	builder.fault.HelpUrl = strings.TrimSpace(helpUrl)
+	return builder
+}
+
+// Attaches the logger which should record this error eventually - see `fault.LogSelf()`. The logger is never serialized.
+func (builder *FaultBuilder) WithLogger(logger *kt_logging.Logger) *FaultBuilder {
+	builder.fault.logger = logger
+	return builder
+}
+
+// Attaches a formatter which is used during message resolution to render the label values into the messages - so e.g. a struct label can be JSON
+// encoded or a `time.Time` label formatted with a custom layout. Without a formatter the default stringification (`fmt.Sprint()`) applies. The labels
+// themselves are not affected, and the formatter is never serialized.
+func (builder *FaultBuilder) WithLabelFormatter(formatter func(key string, value any) string) *FaultBuilder {
+	builder.fault.labelFormatter = formatter
+	return builder
+}
+
 // You can attach the error which caused this error to this error.
+//
+// If the cause (anywhere in its chain) is a `context.DeadlineExceeded` or a `net.Error` timeout then at build time the error automatically gets the
+// `ILLEGALSTATE_ERRCODE_TIMED_OUT` error code (`ILLEGALSTATE_ERRCODE_CANCELLED` if it is a `context.Canceled`) - unless you set error code(s)
+// explicitly. A timeout also makes the error retryable - unless you explicitly decided about retryability with `WithIsRetryable()`. Of course
+// inheritedly not retryable kinds remain not retryable. A cancellation does not make the error retryable.
 func (builder *FaultBuilder) WithCause(e error) *FaultBuilder {
-	builder.fault.cause = e
+	return builder.WithCauses(e)
+}
+
+// Some failures genuinely have several independent root causes (e.g. two downstreams both failed). With this method you can attach all of them.
+// Nil errors are skipped. Please note: this replaces the possibly previously attached cause(s).
+func (builder *FaultBuilder) WithCauses(errs ...error) *FaultBuilder {
+	builder.fault.causes = nil
+	for _, e := range errs {
+		if e != nil {
+			builder.fault.causes = append(builder.fault.causes, e)
+		}
+	}
+	return builder
+}
+
+// The common "add a layer of context but keep the machine signals" pattern: sets the inner Fault as cause (see `WithCause()`) and optionally copies its
+// error codes (with their categories - see `WithStructuredErrorCodes()`) and its safe labels onto this error. Labels already set on this error are not
+// overwritten (see `KeepExisting`). A nil inner Fault (or `NoFault`) is a no-op.
+//
+// Which labels are safe? All labels of a public inner Fault. From a non-public inner Fault only the labels marked with `WithPublicLabels()` are copied
+// (and they remain marked) - just like in case of the public conversion (see `NewPublicFaultFromAnyError()`).
+func (builder *FaultBuilder) WithWrappedFault(inner Fault, inheritCodes bool, inheritLabels bool) *FaultBuilder {
+	if IsNoFault(inner) {
+		return builder
+	}
+	builder.WithCause(inner)
+	if inheritCodes {
+		builder.WithStructuredErrorCodes(inner.GetStructuredErrorCodes()...)
+	}
+	if inheritLabels {
+		labels := inner.GetLabels()
+		if !inner.IsPublic() {
+			publicLabelKeys := inner.GetPublicLabelKeys()
+			maps.DeleteFunc(labels, func(key string, _ any) bool { return !slices.Contains(publicLabelKeys, key) })
+			builder.WithPublicLabels(slices.Sorted(maps.Keys(labels))...)
+		}
+		builder.WithLabelsMerge(labels, KeepExisting)
+	}
 	return builder
 }
 
-// Removes the attached cause from the error - if there was attached any previously.
+// Removes the attached cause(s) from the error - if there was attached any previously.
 func (builder *FaultBuilder) WithoutCause() *FaultBuilder {
-	builder.fault.cause = nil
+	builder.fault.causes = nil
 	return builder
 }
 
@@ -145,31 +451,243 @@ func (builder *FaultBuilder) WithoutCause() *FaultBuilder {
 // the error originates from. We do it in the easiest way: you can put this into a string the way you want :-) That's it.
 // As you can see, if you want you can pass in multiple string elements. If you do so, they will be automatically concatenated
 // using "." separator.
+// An error has one single source - so if you invoke this method again it replaces the previously set source instead of adding one more element to the
+// call stack. See also `WithSourceIfAbsent()`!
 func (builder *FaultBuilder) WithSource(src ...string) *FaultBuilder {
-	builder.fault.callStack = append(builder.fault.callStack, strings.Join(src, "."))
+	source := strings.Join(src, ".")
+	if len(builder.fault.callStack) == 0 {
+		builder.fault.callStack = append(builder.fault.callStack, source)
+	} else {
+		builder.fault.callStack[0] = source
+	}
+	return builder
+}
+
+// Same as `WithSource()` but it only sets the source if it was not set yet. Useful e.g. in helper constructors which want to provide a default source
+// but the caller might have already set a more precise one.
+func (builder *FaultBuilder) WithSourceIfAbsent(src ...string) *FaultBuilder {
+	if len(builder.fault.callStack) == 0 {
+		builder.WithSource(src...)
+	}
 	return builder
 }
 
+// Same as `WithSource()` but the source is determined from the runtime - it becomes "<package>.<function>" of the caller (e.g. "myservice.(*Repo).Load"
+// where the package is the last element of the import path)
+// so it can not drift from reality. With `skip` 0 the function invoking this method is taken, 1 means its caller and so on - useful in helper functions.
+// If the caller can not be determined the source remains untouched.
+//
+// Please note: this is opt-in - determining the caller costs a bit more than simply passing the strings (but still far less than capturing a stacktrace).
+func (builder *FaultBuilder) WithAutoSource(skip int) *FaultBuilder {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return builder
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return builder
+	}
+	// the name is the full import path qualified - we only keep the last package element
+	name := fn.Name()
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return builder.WithSource(name)
+}
+
 // You can add error codes to this error - multiple in one call.
 // Error codes are simply strings. There are several predefined ones - see `*_ERRCODE_*` constants - but you can also
 // define you owns of course.
+// Codes are trimmed (whitespaces) and empty codes are simply ignored.
 func (builder *FaultBuilder) WithErrorCodes(c ...string) *FaultBuilder {
-	builder.errCodes.AddAll(c...)
+	for _, code := range c {
+		builder.WithErrorCode(code)
+	}
+	return builder
+}
+
+// Same as `WithErrorCodes()` but reads better if you just want to add one single error code.
+// The code is trimmed (whitespaces) and empty code is simply ignored.
+func (builder *FaultBuilder) WithErrorCode(code string) *FaultBuilder {
+	code = strings.TrimSpace(code)
+	if code != "" {
+		builder.errCodes.Add(code)
+	}
+	return builder
+}
+
+// Same as `WithErrorCode()` but only applied if `cond` is true - so conditional construction does not break the builder chain.
+func (builder *FaultBuilder) WithErrorCodeIf(cond bool, code string) *FaultBuilder {
+	if cond {
+		builder.WithErrorCode(code)
+	}
+	return builder
+}
+
+// You can add error codes in structured form (category + code) to this error - see `ErrorCode` type. The flat string based API (e.g. `HasErrorCode()`)
+// works with the `Code` part.
+// Codes are trimmed (whitespaces) and empty codes are simply ignored.
+func (builder *FaultBuilder) WithStructuredErrorCodes(c ...ErrorCode) *FaultBuilder {
+	for _, ec := range c {
+		code := strings.TrimSpace(ec.Code)
+		if code == "" {
+			continue
+		}
+		builder.errCodes.Add(code)
+		if ec.Category != "" {
+			if builder.fault.errorCodeCategories == nil {
+				builder.fault.errorCodeCategories = make(map[string]string)
+			}
+			builder.fault.errorCodeCategories[code] = ec.Category
+		}
+	}
+	return builder
+}
+
+// You can add free-form tags (just strings) to this error for categorization - like "billing" or "critical-path". Unlike labels, tags never appear in messages.
+// Tags are a set so duplicates simply collapse. Tags are trimmed (whitespaces) and empty tags are simply ignored.
+// Tags are serialized in the full JSON form (see `fault.ToFullJSON()`) but not in the natural form.
+func (builder *FaultBuilder) WithTags(tags ...string) *FaultBuilder {
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			builder.tags.Add(tag)
+		}
+	}
 	return builder
 }
 
 // If you changed your mind you can remove specific error codes from the error.
 func (builder *FaultBuilder) WithoutErrorCodes(c ...string) *FaultBuilder {
-	builder.errCodes.RemoveAll(c...)
+	for _, code := range c {
+		code = strings.TrimSpace(code)
+		builder.errCodes.Remove(code)
+		delete(builder.fault.errorCodeCategories, code)
+	}
 	return builder
 }
 
+// Sets the error codes of this error to the given ones - all previous codes (and their categories) will be removed. Useful if you re-derive an error and
+// want a clean code set. See also `WithErrorCodes()`!
+// Codes are trimmed (whitespaces) and empty codes are simply ignored.
+func (builder *FaultBuilder) WithExactErrorCodes(c ...string) *FaultBuilder {
+	builder.errCodes = ktsets.NewSet[string]()
+	builder.fault.errorCodeCategories = nil
+	return builder.WithErrorCodes(c...)
+}
+
 // Attaching a label (key-value pair) to this error.
+// Please note: the number of labels and the length of the string values can be capped - see `SetMaxLabelCount()` and `SetMaxLabelValueLength()`.
 func (builder *FaultBuilder) WithLabel(key string, value any) *FaultBuilder {
 	builder.fault.AddLabel(key, value)
 	return builder
 }
 
+// Same as `WithLabel()` but only applied if `cond` is true - so conditional construction does not break the builder chain.
+func (builder *FaultBuilder) WithLabelIf(cond bool, key string, value any) *FaultBuilder {
+	if cond {
+		builder.WithLabel(key, value)
+	}
+	return builder
+}
+
+// Sets how serious this error is - see `Severity`. By default every Fault is `SeverityError`. Non-fatal Faults (`SeverityWarning`, `SeverityInfo`) can
+// travel alongside a successful result - see `fault.ToWarningJSON()` - and their HTTP status code is 200.
+func (builder *FaultBuilder) WithSeverity(severity Severity) *FaultBuilder {
+	builder.fault.Severity = severity
+	return builder
+}
+
+// Attaching a hint how long the caller should wait before retrying - typically used with `ILLEGALSTATE_ERRCODE_RATE_LIMITED` error code (e.g. to
+// render a "Retry-After" HTTP header). The hint is stored in milliseconds as label "retryAfterMs" - you can read it back with `fault.GetRetryAfter()`.
+func (builder *FaultBuilder) WithRetryAfter(d time.Duration) *FaultBuilder {
+	builder.fault.AddLabel(retryAfterLabel, d.Milliseconds())
+	return builder
+}
+
+// Marks this error as a transient dependency failure ("downstream is momentarily down") in one call: sets the error retryable (if the kind allows -
+// see `WithIsRetryable()`), adds `ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE` error code and records the dependency as label "dependency". If no
+// retry-after hint was given so far (see `WithRetryAfter()`) then a default of 5 seconds is set.
+// With `IllegalStateFault` kind this maps to HTTP 503.
+func (builder *FaultBuilder) WithTransientDependency(depName string) *FaultBuilder {
+	builder.WithIsRetryable(true).
+		WithErrorCode(ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE).
+		WithLabel("dependency", depName)
+	if _, found := builder.fault.GetLabel(retryAfterLabel); !found {
+		builder.WithRetryAfter(defaultTransientRetryAfter)
+	}
+	return builder
+}
+
+// Attaching the latency (how long the failed operation took) to this error - useful for performance related errors like timeouts, slow dependencies.
+// The latency is stored in milliseconds as label "latencyMs" - you can read it back with `fault.GetLatency()`.
+func (builder *FaultBuilder) WithLatency(d time.Duration) *FaultBuilder {
+	builder.fault.AddLabel(latencyLabel, d.Milliseconds())
+	return builder
+}
+
+// Snapshots the values of the given keys from the context into labels - so request-scoped data (e.g. user id, tenant id, request id) does not need to
+// be plumbed into the error manually. Each key becomes a label with the same name, keys missing from the context are simply skipped.
+//
+// Please note: the values are only found if they were put into the context with the very same `ContextKey` typed keys.
+func (builder *FaultBuilder) WithContext(ctx context.Context, keys ...ContextKey) *FaultBuilder {
+	if ctx == nil {
+		return builder
+	}
+	for _, key := range keys {
+		if value := ctx.Value(key); value != nil {
+			builder.fault.AddLabel(string(key), value)
+		}
+	}
+	return builder
+}
+
+// Attaching the context of a failed outgoing HTTP request to this error - so HTTP related errors look the same way across services.
+// The following labels are populated: "http.method", "http.url" and "http.requestHeaders" (the latter only if headers are given).
+// Sensitive data is redacted: the values of the "Authorization", "Proxy-Authorization", "Cookie" and "Set-Cookie" headers are replaced with "[REDACTED]"
+// and so is the password in the url (if there is any) and the value of the url query parameters which typically carry credentials (like "access_token",
+// "api_key", "password" etc - case insensitive). If the url can not be parsed then it is not attached at all - as it could not be redacted.
+func (builder *FaultBuilder) WithHttpRequestContext(method string, reqUrl string, reqHeaders http.Header) *FaultBuilder {
+	builder.fault.AddLabel(httpMethodLabel, method)
+	if parsedUrl, err := url.Parse(reqUrl); err == nil {
+		parsedUrl.RawQuery = redactedHttpQuery(parsedUrl.RawQuery)
+		builder.fault.AddLabel(httpUrlLabel, parsedUrl.Redacted())
+	}
+	if len(reqHeaders) > 0 {
+		builder.fault.AddLabel(httpRequestHeadersLabel, redactedHttpHeaders(reqHeaders))
+	}
+	return builder
+}
+
+// The counterpart of `WithHttpRequestContext()` - attaching the response of the failed HTTP request to this error.
+// The following labels are populated: "http.status", "http.responseHeaders" (only if headers are given) and "http.responseBody" (only if `bodySnippet`
+// is not empty). Sensitive headers are redacted the same way as in `WithHttpRequestContext()` and the body snippet is truncated to 512 characters.
+func (builder *FaultBuilder) WithHttpResponseContext(status int, respHeaders http.Header, bodySnippet string) *FaultBuilder {
+	builder.fault.AddLabel(httpStatusLabel, status)
+	if len(respHeaders) > 0 {
+		builder.fault.AddLabel(httpResponseHeadersLabel, redactedHttpHeaders(respHeaders))
+	}
+	if bodySnippet != "" {
+		if runes := []rune(bodySnippet); len(runes) > httpBodySnippetMaxLength {
+			bodySnippet = string(runes[:httpBodySnippetMaxLength]) + "..."
+		}
+		builder.fault.AddLabel(httpResponseBodyLabel, bodySnippet)
+	}
+	return builder
+}
+
+// You can explicitly mark labels (by their keys) as safe to be published. This is important when a non-public error is converted into a public one with
+// `NewPublicFaultFromAnyError()` - if there is at least one label marked like this then only the marked labels are carried forward into the public error
+// (and only if they are needed to resolve messages). See the description of `NewPublicFaultFromAnyError()`!
+func (builder *FaultBuilder) WithPublicLabels(keys ...string) *FaultBuilder {
+	for _, key := range keys {
+		if key != "" && !slices.Contains(builder.fault.publicLabelKeys, key) {
+			builder.fault.publicLabelKeys = append(builder.fault.publicLabelKeys, key)
+		}
+	}
+	return builder
+}
+
 // If you changed your mind you can remove specific labels (key-value pair) from this error.
 func (builder *FaultBuilder) WithoutLabels(keys ...string) *FaultBuilder {
 	if builder.fault.Labels == nil {
@@ -191,6 +709,36 @@ func (builder *FaultBuilder) WithLabels(labels map[string]any) *FaultBuilder {
 	return builder
 }
 
+// Like `WithLabels()` but you can decide what happens with the keys which already exist - see `MergeStrategy`. With `Overwrite` (the default) this
+// is the same as `WithLabels()`, with `KeepExisting` the already set labels win.
+func (builder *FaultBuilder) WithLabelsMerge(labels map[string]any, strategy MergeStrategy) *FaultBuilder {
+	if strategy != KeepExisting {
+		return builder.WithLabels(labels)
+	}
+	toAdd := make(map[string]any, len(labels))
+	for key, value := range labels {
+		if _, found := builder.fault.GetLabel(key); !found {
+			toAdd[key] = value
+		}
+	}
+	builder.fault.AddLabels(toAdd)
+	return builder
+}
+
+// Attaching the exported fields of the given struct (or pointer to struct) as labels - so you do not need to enumerate them manually. The label key is
+// the field name unless it is tagged with `label:"name"`, fields tagged with `label:"-"` are skipped and the value of fields tagged with
+// `label:"name,redact"` is replaced with "[REDACTED]". Nested structs are flattened with dotted keys (e.g. "user.id"), embedded structs are promoted the
+// same way as with JSON. Structs implementing `fmt.Stringer` (e.g. `time.Time`) are not flattened but kept as values, nil struct pointers are skipped.
+// Self referencing pointer graphs are fine - a struct already being flattened (up in the same path) is skipped.
+//
+// Anything else than a struct (or a nil pointer) is simply ignored.
+func (builder *FaultBuilder) WithLabelsFromStruct(v any) *FaultBuilder {
+	labels := make(map[string]any)
+	collectStructLabels(reflect.ValueOf(v), "", labels, make(map[structLabelsVisit]bool))
+	builder.fault.AddLabels(labels)
+	return builder
+}
+
 // Sets the labels (key-value pairs) attached to this error to the given map - all previous labels will be removed.
 func (builder *FaultBuilder) WithExactLabels(labels map[string]any) *FaultBuilder {
 	if len(labels) == 0 {
@@ -198,6 +746,130 @@ func (builder *FaultBuilder) WithExactLabels(labels map[string]any) *FaultBuilde
 		return builder
 	}
 	builder.fault.Labels = make(map[string]any, len(labels))
-	maps.Copy(builder.fault.Labels, labels)
+	builder.fault.AddLabels(labels)
 	return builder
 }
+
+// Checks the given errors (with their chains) and returns `ILLEGALSTATE_ERRCODE_TIMED_OUT` if any of them is a timeout, `ILLEGALSTATE_ERRCODE_CANCELLED`
+// if any of them is a cancellation or empty string otherwise. Timeout wins over cancellation.
+func timeoutErrorCodeOf(errs []error) string {
+	code := ""
+	for _, err := range errs {
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+			return ILLEGALSTATE_ERRCODE_TIMED_OUT
+		}
+		if errors.Is(err, context.Canceled) {
+			code = ILLEGALSTATE_ERRCODE_CANCELLED
+		}
+	}
+	return code
+}
+
+// Identifies a struct (behind a pointer) during `collectStructLabels()` - the type is needed as a struct and its first field share the same address.
+type structLabelsVisit struct {
+	pointer uintptr
+	typ     reflect.Type
+}
+
+// Collects the exported fields of the struct behind `value` into `labels` - see `WithLabelsFromStruct()`. The structs behind the pointers of the
+// current path are tracked in `visiting` so cycles are cut.
+func collectStructLabels(value reflect.Value, prefix string, labels map[string]any, visiting map[structLabelsVisit]bool) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return
+		}
+		visit := structLabelsVisit{pointer: value.Pointer(), typ: value.Type()}
+		if visiting[visit] {
+			return
+		}
+		visiting[visit] = true
+		defer delete(visiting, visit)
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("label"), ",")
+		if name == "-" || !(field.IsExported() || field.Anonymous && isFlattenableStruct(fieldValue)) {
+			// the exported fields of embedded non-exported structs are promoted - the same way as with JSON - but non-exported values are never taken
+			continue
+		}
+		if fieldValue.Kind() == reflect.Pointer && fieldValue.IsNil() && fieldValue.Type().Elem().Kind() == reflect.Struct {
+			continue
+		}
+		if name == "" {
+			if field.Anonymous && isFlattenableStruct(fieldValue) {
+				collectStructLabels(fieldValue, prefix, labels, visiting)
+				continue
+			}
+			name = field.Name
+		}
+		key := prefix + name
+		switch {
+		case slices.Contains(strings.Split(options, ","), "redact"):
+			labels[key] = redactedValue
+		case isFlattenableStruct(fieldValue):
+			collectStructLabels(fieldValue, key+".", labels, visiting)
+		default:
+			labels[key] = fieldValue.Interface()
+		}
+	}
+}
+
+func isFlattenableStruct(value reflect.Value) bool {
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return false
+	}
+	if value.Type().Implements(reflect.TypeFor[fmt.Stringer]()) {
+		return false
+	}
+	return reflect.Indirect(value).Kind() == reflect.Struct
+}
+
+// Flattens the headers into a map (multiple values are joined with ", ") - values of the sensitive headers are redacted.
+func redactedHttpHeaders(headers http.Header) map[string]string {
+	ret := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if slices.ContainsFunc(sensitiveHttpHeaders, func(sensitive string) bool { return strings.EqualFold(sensitive, name) }) {
+			ret[name] = redactedValue
+		} else {
+			ret[name] = strings.Join(values, ", ")
+		}
+	}
+	return ret
+}
+
+// Returns the raw url query with the values of the sensitive parameters replaced with "[REDACTED]" - the order of the parameters is kept.
+func redactedHttpQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+	params := strings.Split(rawQuery, "&")
+	for idx, param := range params {
+		rawKey, _, _ := strings.Cut(param, "=")
+		key := rawKey
+		if unescapedKey, err := url.QueryUnescape(rawKey); err == nil {
+			key = unescapedKey
+		}
+		if slices.ContainsFunc(sensitiveHttpQueryParams, func(sensitive string) bool { return strings.EqualFold(sensitive, key) }) {
+			params[idx] = rawKey + "=" + redactedValue
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// In strict audiences mode logs a warning for each unregistered audience - see `SetStrictAudiences()`.
+func warnIfUnregisteredAudiences(audiences ...string) {
+	if !IsStrictAudiences() {
+		return
+	}
+	for _, audience := range audiences {
+		if !IsRegisteredAudience(audience) {
+			getDefaultLogger().Warn("Message template is set for unregistered audience '%s' - see `RegisterAudience()`", audience)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// OpenTelemetry integration for `kt_errors.Fault`s. This lives in its own module so the OpenTelemetry dependencies do not leak into the core library.
+package faultotel
+
+import (
+	"fmt"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Span attribute carrying the kind of the Fault
+	AttrFaultKind = "fault.kind"
+	// Span attribute carrying the retryable flag of the Fault
+	AttrFaultRetryable = "fault.retryable"
+	// Span attribute carrying the error codes of the Fault
+	AttrFaultErrorCodes = "fault.error_codes"
+	// Span attribute carrying the public flag of the Fault
+	AttrFaultPublic = "fault.public"
+	// Labels of public Faults are attached to the exception event with this prefix
+	AttrFaultLabelPrefix = "fault.label."
+)
+
+// Records the given Fault on the span in one go:
+//   - sets the status of the span to Error with the resolved message of the Fault (the unresolved message template for non-public Faults)
+//   - sets the `fault.kind`, `fault.retryable`, `fault.public` and `fault.error_codes` attributes on the span
+//   - records an exception event carrying the same attributes - and in case of public Faults the labels too (as `fault.label.<key>` attributes)
+//
+// IMPORTANT! Labels of non-public Faults are never attached - they might carry sensitive data. This is why in case of non-public Faults neither the
+// status description nor the recorded exception contains the resolved message - only the message template.
+func RecordOnSpan(span trace.Span, fault kt_errors.Fault) {
+	if span == nil || kt_errors.IsNoFault(fault) {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String(AttrFaultKind, fault.GetKind()),
+		attribute.Bool(AttrFaultRetryable, fault.IsRetryable()),
+		attribute.Bool(AttrFaultPublic, fault.IsPublic()),
+		attribute.StringSlice(AttrFaultErrorCodes, fault.GetErrorCodes()),
+	}
+	description := fault.GetMessage()
+	var recordedErr error = fault
+	if !fault.IsPublic() {
+		description = fault.GetMessageTemplate()
+		recordedErr = nonPublicFaultError{fault: fault}
+	}
+	span.SetStatus(codes.Error, description)
+	span.SetAttributes(attrs...)
+
+	eventAttrs := attrs
+	if fault.IsPublic() {
+		for key, value := range fault.GetLabels() {
+			eventAttrs = append(eventAttrs, attribute.String(AttrFaultLabelPrefix+key, fmt.Sprint(value)))
+		}
+	}
+	span.RecordError(recordedErr, trace.WithAttributes(eventAttrs...))
+}
+
+// Recorded instead of non-public Faults - its error string is built from the unresolved message template so the label values do not leak.
+type nonPublicFaultError struct {
+	fault kt_errors.Fault
+}
+
+func (e nonPublicFaultError) Error() string {
+	return fmt.Sprintf("%s: %s (retryable: %t, errorCodes: %s)",
+		e.fault.GetKind(), e.fault.GetMessageTemplate(), e.fault.IsRetryable(), e.fault.GetErrorCodesString())
+}
+
+func (e nonPublicFaultError) Unwrap() error {
+	return e.fault
+}
@@ -0,0 +1,90 @@
+package faultotel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors/faultotel"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func recordFault(fault kt_errors.Fault) sdktrace.ReadOnlySpan {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	_, span := provider.Tracer("test").Start(context.Background(), "operation")
+	faultotel.RecordOnSpan(span, fault)
+	span.End()
+	return recorder.Ended()[0]
+}
+
+func attrMap(attrs []attribute.KeyValue) map[string]attribute.Value {
+	ret := make(map[string]attribute.Value, len(attrs))
+	for _, attr := range attrs {
+		ret[string(attr.Key)] = attr.Value
+	}
+	return ret
+}
+
+func TestRecordOnSpan(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Public fault
+
+	// ---- GIVEN
+	fault := kt_errors.NewPublicFaultBuilder(kt_errors.ValidationFault).
+		WithMessageTemplate("invalid value {value}").
+		WithErrorCodes(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+		WithLabel("value", 42).
+		Build()
+
+	// ---- WHEN
+	span := recordFault(fault)
+
+	// ---- THEN
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "invalid value 42", span.Status().Description)
+	spanAttrs := attrMap(span.Attributes())
+	assert.Equal(t, "validation", spanAttrs[faultotel.AttrFaultKind].AsString())
+	assert.False(t, spanAttrs[faultotel.AttrFaultRetryable].AsBool())
+	assert.Equal(t, []string{"invalid_value"}, spanAttrs[faultotel.AttrFaultErrorCodes].AsStringSlice())
+	assert.Equal(t, 1, len(span.Events()))
+	assert.Equal(t, "exception", span.Events()[0].Name)
+	eventAttrs := attrMap(span.Events()[0].Attributes)
+	assert.Equal(t, "validation", eventAttrs[faultotel.AttrFaultKind].AsString())
+	assert.Equal(t, "42", eventAttrs["fault.label.value"].AsString())
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Non-public fault - no labels attached
+
+	// ---- GIVEN
+	fault = kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("db {dbHost} is down").
+		WithIsRetryable(true).
+		WithLabel("dbHost", "secret-db.internal").
+		Build()
+
+	// ---- WHEN
+	span = recordFault(fault)
+
+	// ---- THEN
+	assert.Equal(t, codes.Error, span.Status().Code)
+	// the sensitive label does not leak through the status or the exception
+	assert.Equal(t, "db {dbHost} is down", span.Status().Description)
+	assert.Equal(t, "illegal_state: db {dbHost} is down (retryable: true, errorCodes: [])",
+		attrMap(span.Events()[0].Attributes)["exception.message"].AsString())
+	spanAttrs = attrMap(span.Attributes())
+	assert.Equal(t, "illegal_state", spanAttrs[faultotel.AttrFaultKind].AsString())
+	assert.True(t, spanAttrs[faultotel.AttrFaultRetryable].AsBool())
+	eventAttrs = attrMap(span.Events()[0].Attributes)
+	_, found := eventAttrs["fault.label.dbHost"]
+	assert.False(t, found)
+}
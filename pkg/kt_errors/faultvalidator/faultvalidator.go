@@ -0,0 +1,63 @@
+// go-playground/validator integration for `kt_errors.Fault`s. This lives in its own module so the validator does not become a dependency of the core
+// library.
+package faultvalidator
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+)
+
+// Prefix of the labels carrying the failed fields - see `FromValidatorErrors()`
+const LabelFieldPrefix = "field."
+
+// Turns the result of a `validator.Validate.Struct()` (or `Var()`) call into a single public `kt_errors.ValidationFault` (so it maps to HTTP 400).
+// Every failed field is attached as label "field.<name>" with the failed validation tag as value (e.g. "field.email" = "email") - where the name is the
+// namespace of the field without the top level struct name (so nested fields look like "address.city"). The number of failed fields is attached as
+// label "fieldCount" - which is used in the message too.
+//
+// The Fault always gets `kt_errors.VALIDATION_ERRCODE_INVALID_VALUE` error code, and also `kt_errors.VALIDATION_ERRCODE_MISSING_MANDATORY` if a
+// "required..." tag failed.
+//
+// Returns nil if `err` is nil. If `err` is not a `validator.ValidationErrors` (e.g. a `*validator.InvalidValidationError` because a nil was validated)
+// then that is a mistake in the code - so a non-public `kt_errors.IllegalStateFault` with `kt_errors.ILLEGALSTATE_ERRCODE_CODE_BUG` error code is
+// returned (with `err` as cause).
+func FromValidatorErrors(err error) kt_errors.Fault {
+	if err == nil {
+		return nil
+	}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+			WithMessageTemplate("Validation could not be executed").
+			WithErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_CODE_BUG).
+			WithCause(err).
+			Build()
+	}
+
+	builder := kt_errors.NewPublicFaultBuilder(kt_errors.ValidationFault).
+		WithMessageTemplate("Validation failed on {fieldCount} field(s)").
+		WithErrorCode(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE)
+	fields := make(map[string]bool, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		name := fieldName(fieldErr)
+		fields[name] = true
+		builder.WithLabel(LabelFieldPrefix+name, fieldErr.Tag())
+		if strings.HasPrefix(fieldErr.Tag(), "required") {
+			builder.WithErrorCode(kt_errors.VALIDATION_ERRCODE_MISSING_MANDATORY)
+		}
+	}
+	return builder.
+		WithLabel("fieldCount", len(fields)).
+		Build()
+}
+
+// Returns the namespace of the field without the top level struct name - or simply the field name if there is no namespace (e.g. `Var()` validation).
+func fieldName(fieldErr validator.FieldError) string {
+	if _, name, found := strings.Cut(fieldErr.Namespace(), "."); found {
+		return name
+	}
+	return fieldErr.Field()
+}
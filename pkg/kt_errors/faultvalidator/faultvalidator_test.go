@@ -0,0 +1,87 @@
+package faultvalidator_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors/faultvalidator"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type signupRequest struct {
+	Email   string  `json:"email" validate:"required,email"`
+	Age     int     `json:"age" validate:"gte=18"`
+	Address address `json:"address"`
+}
+
+func newValidator() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	// use the JSON names - just like the clients see the fields
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("json"), ",")[0]
+	})
+	return validate
+}
+
+func TestFromValidatorErrors(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Struct failing two validation tags
+
+	// ---- GIVEN
+	err := newValidator().Struct(signupRequest{Email: "not-an-email", Age: 12, Address: address{City: "Budapest"}})
+
+	// ---- WHEN
+	fault := faultvalidator.FromValidatorErrors(err)
+
+	// ---- THEN
+	assert.Equal(t, kt_errors.ValidationFault, fault.GetKind())
+	assert.True(t, fault.IsPublic())
+	assert.Equal(t, 400, fault.GetHttpStatusCode())
+	assert.Equal(t, []string{kt_errors.VALIDATION_ERRCODE_INVALID_VALUE}, fault.GetErrorCodes())
+	assert.Equal(t, map[string]any{"field.email": "email", "field.age": "gte", "fieldCount": 2}, fault.GetLabels())
+	assert.Equal(t, "Validation failed on 2 field(s)", fault.GetMessage())
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Missing nested mandatory field
+
+	// ---- GIVEN
+	err = newValidator().Struct(signupRequest{Email: "john@example.com", Age: 20})
+
+	// ---- WHEN
+	fault = faultvalidator.FromValidatorErrors(err)
+
+	// ---- THEN
+	assert.True(t, fault.HasAllErrorCodes(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE, kt_errors.VALIDATION_ERRCODE_MISSING_MANDATORY))
+	assert.Equal(t, map[string]any{"field.address.city": "required", "fieldCount": 1}, fault.GetLabels())
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// No error and not a validation result
+
+	// ---- WHEN / THEN
+	assert.Nil(t, faultvalidator.FromValidatorErrors(nil))
+	assert.Nil(t, faultvalidator.FromValidatorErrors(newValidator().Struct(signupRequest{Email: "john@example.com", Age: 20, Address: address{City: "Budapest"}})))
+
+	// ---- WHEN
+	fault = faultvalidator.FromValidatorErrors(newValidator().Struct(nil))
+	// ---- THEN
+	assert.Equal(t, kt_errors.IllegalStateFault, fault.GetKind())
+	assert.False(t, fault.IsPublic())
+	assert.True(t, fault.HasErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_CODE_BUG))
+	var invalidValidationErr *validator.InvalidValidationError
+	assert.True(t, errors.As(fault.GetCause(), &invalidValidationErr))
+}
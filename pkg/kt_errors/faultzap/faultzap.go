@@ -0,0 +1,30 @@
+// zap integration for `kt_errors.Fault`s. This lives in its own module so zap does not become a direct dependency of the core library.
+package faultzap
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"go.uber.org/zap"
+)
+
+// Returns the given Fault as zap fields - so zap users can log Faults structurally. The fields are the ones of `fault.ToLogFields()` (sorted by key):
+//   - "kind", "retryable", "errorCodes" and "source" - always
+//   - "reference" - if the Fault has one
+//   - "message" - the resolved default message, only for public Faults
+//   - "label.<key>" - the labels flattened with "label." prefix, only for public Faults
+//
+// IMPORTANT! The message and the labels of non-public Faults are never attached - they might carry sensitive data. For a nil Fault or the
+// `kt_errors.NoFault` sentinel no fields are returned.
+func Fields(fault kt_errors.Fault) []zap.Field {
+	if kt_errors.IsNoFault(fault) {
+		return make([]zap.Field, 0)
+	}
+	logFields := fault.ToLogFields()
+	fields := make([]zap.Field, 0, len(logFields))
+	for _, key := range slices.Sorted(maps.Keys(logFields)) {
+		fields = append(fields, zap.Any(key, logFields[key]))
+	}
+	return fields
+}
@@ -0,0 +1,84 @@
+package faultzap_test
+
+import (
+	"testing"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors/faultzap"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func logFault(fault kt_errors.Fault) map[string]any {
+	core, logs := observer.New(zapcore.DebugLevel)
+	zap.New(core).Error("operation failed", faultzap.Fields(fault)...)
+	return logs.All()[0].ContextMap()
+}
+
+func TestFields(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Public Fault - message and labels are there
+
+	// ---- GIVEN
+	fault := kt_errors.NewPublicFaultBuilder(kt_errors.ValidationFault).
+		WithMessageTemplate("field {field} is invalid").
+		WithErrorCodes(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+		WithLabel("field", "name").
+		WithSource("api.Save").
+		WithReference("ERR-TEST01").
+		Build()
+
+	// ---- WHEN
+	fields := faultzap.Fields(fault)
+	// ---- THEN
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, field.Key)
+	}
+	assert.Equal(t, []string{"errorCodes", "kind", "label.field", "message", "reference", "retryable", "source"}, keys)
+	assert.Equal(t, map[string]any{
+		"kind":        kt_errors.ValidationFault,
+		"retryable":   false,
+		"errorCodes":  []any{kt_errors.VALIDATION_ERRCODE_INVALID_VALUE},
+		"source":      "api.Save",
+		"reference":   "ERR-TEST01",
+		"message":     "field name is invalid",
+		"label.field": "name",
+	}, logFault(fault))
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Non-public Fault - message and labels are redacted
+
+	// ---- GIVEN
+	fault = kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("db {dbHost} is down").
+		WithLabel("dbHost", "secret-db.internal").
+		WithSource("repo.Save").
+		Build()
+
+	// ---- WHEN
+	logged := logFault(fault)
+	// ---- THEN
+	assert.Equal(t, map[string]any{
+		"kind":       kt_errors.IllegalStateFault,
+		"retryable":  false,
+		"errorCodes": []any{},
+		"source":     "repo.Save",
+	}, logged)
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// No Fault - no fields
+
+	// ---- WHEN / THEN
+	assert.Empty(t, faultzap.Fields(nil))
+	assert.Empty(t, faultzap.Fields(kt_errors.NoFault))
+}
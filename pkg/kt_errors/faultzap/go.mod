@@ -0,0 +1,31 @@
+module github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors/faultzap
+
+go 1.24.0
+
+require (
+	github.com/keytiles/lib-errorhandling-golang/v2 v2.1.0
+	github.com/stretchr/testify v1.11.1
+	go.uber.org/zap v1.27.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/keytiles/lib-logging-golang/v2 v2.1.0 // indirect
+	github.com/keytiles/lib-sets-golang v1.2.0 // indirect
+	github.com/keytiles/lib-utils-golang v1.0.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/sanity-io/litter v1.5.8 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	golang.org/x/net v0.47.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251029180050-ab9386a59fda // indirect
+	google.golang.org/grpc v1.78.0 // indirect
+	google.golang.org/protobuf v1.36.10 // indirect
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// only for developing in this repository - consumers ignore it and get the required core release (which must be tagged first)
+replace github.com/keytiles/lib-errorhandling-golang/v2 => ../../..
@@ -0,0 +1,176 @@
+package kt_errors
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keytiles/lib-logging-golang/v2/pkg/kt_logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The incoming gRPC metadata key `UnaryServerInterceptor()` takes the transaction id from.
+const GRPC_TRANSACTIONID_METADATA_KEY = "x-transaction-id"
+
+// The label `NewFaultFromGrpcStatus()` attaches the original gRPC code with
+const grpcCodeLabel = "grpc.code"
+
+// The label `NewFaultFromGrpcStatus()` attaches the status message with - the message template simply refers to it
+const grpcMessageLabel = "grpcMessage"
+
+// Returns the gRPC status you should respond with if you fail from the given `Fault` - the code is `GetGrpcStatusCodeForFault()`, the message is the
+// resolved message of the Fault.
+//
+// IMPORTANT! In case the `Fault` is not public then the message is empty - so nothing can leak.
+//
+// Note: there is an alias for this method as `fault.ToGrpcStatus()` - if you prefer that style more.
+func GetGrpcStatusForFault(fault Fault) *status.Status {
+	message := ""
+	if fault != nil && fault.IsPublic() {
+		message = fault.GetMessage()
+	}
+	return status.New(GetGrpcStatusCodeForFault(fault), message)
+}
+
+// Returns a gRPC unary server interceptor which turns the errors returned by the handlers into proper gRPC statuses. Faults and other errors are converted
+// with `NewPublicFaultFromAnyError()` - so non-public ones are logged (with the given logger - or the default one if nil) and only the safe, public data
+// reaches the client via `fault.ToGrpcStatus()`. The transaction id for the conversion is taken from the incoming metadata (see
+// `GRPC_TRANSACTIONID_METADATA_KEY`) if present.
+//
+// Exceptions - so handlers already returning proper gRPC statuses keep working:
+//   - errors carrying a gRPC status (see `status.FromError()`) are returned as they are
+//   - `context.DeadlineExceeded` and `context.Canceled` (also wrapped) become DeadlineExceeded and Canceled statuses
+//
+// You can pass conversion options too - see `OptionXXX()` methods.
+func UnaryServerInterceptor(logger *kt_logging.Logger, options ...ConversionOption) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if isFault, fault := IsFault(err); isFault {
+			if IsNoFault(fault) {
+				return resp, nil
+			}
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return nil, status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+		} else if errors.Is(err, context.Canceled) {
+			return nil, status.Error(codes.Canceled, context.Canceled.Error())
+		} else if _, isStatus := status.FromError(err); isStatus {
+			return nil, err
+		}
+		publicFault := NewPublicFaultFromAnyError(err, transactionIdFromIncomingContext(ctx), logger, options...)
+		return nil, publicFault.ToGrpcStatus().Err()
+	}
+}
+
+// Creates a Fault from a gRPC status - the counterpart of `GetGrpcStatusForFault()`, useful on the client side to get back a rich Fault from the status
+// the server responded with. The code is attached as label "grpc.code" and the status message as label "grpcMessage" - the message template is simply
+// "{grpcMessage}", so the remote text is never resolved as a template (placeholders in it stay as they are).
+//
+// The Fault is public - except for Unknown, Internal and DataLoss codes: the message of these is the downstream's internal detail which must not be
+// forwarded to our clients.
+//
+// The mapping is:
+//   - InvalidArgument - `ValidationFault`
+//   - Unauthenticated - `AuthenticationFault`
+//   - PermissionDenied - `AuthorizationFault` with `AUTHORIZATION_NO_PERMISSION` error code
+//   - NotFound - `ResourceNotFoundFault`
+//   - AlreadyExists - `ConstraintViolationFault` with `CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST` error code
+//   - FailedPrecondition - `ConstraintViolationFault` with `CONSTRAINTVIOLATION_ERRCODE_PRECONDITION_FAILED` error code
+//   - Aborted - `ConstraintViolationFault` with `CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT` error code
+//   - ResourceExhausted - `IllegalStateFault` with `ILLEGALSTATE_ERRCODE_RATE_LIMITED` error code
+//   - Unavailable - `IllegalStateFault` with `ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE` error code
+//   - DeadlineExceeded - `IllegalStateFault` with `ILLEGALSTATE_ERRCODE_TIMED_OUT` error code
+//   - Canceled - `IllegalStateFault` with `ILLEGALSTATE_ERRCODE_CANCELLED` error code
+//   - Unimplemented - `NotImplementedFault`
+//   - anything else - `RuntimeFault`
+//
+// The Fault is retryable for ResourceExhausted, Unavailable and DeadlineExceeded - not retryable otherwise. Returns `nil` for a nil or OK status.
+func NewFaultFromGrpcStatus(grpcStatus *status.Status) Fault {
+	if grpcStatus == nil || grpcStatus.Code() == codes.OK {
+		return nil
+	}
+	return newFaultBuilderFromGrpcStatus(grpcStatus).Build()
+}
+
+// The builder of `NewFaultFromGrpcStatus()` - so a cause can still be attached.
+func newFaultBuilderFromGrpcStatus(grpcStatus *status.Status) *FaultBuilder {
+	kind := RuntimeFault
+	var errCode string
+	switch grpcStatus.Code() {
+	case codes.InvalidArgument:
+		kind = ValidationFault
+	case codes.Unauthenticated:
+		kind = AuthenticationFault
+	case codes.PermissionDenied:
+		kind = AuthorizationFault
+		errCode = AUTHORIZATION_NO_PERMISSION
+	case codes.NotFound:
+		kind = ResourceNotFoundFault
+	case codes.AlreadyExists:
+		kind = ConstraintViolationFault
+		errCode = CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST
+	case codes.FailedPrecondition:
+		kind = ConstraintViolationFault
+		errCode = CONSTRAINTVIOLATION_ERRCODE_PRECONDITION_FAILED
+	case codes.Aborted:
+		kind = ConstraintViolationFault
+		errCode = CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT
+	case codes.ResourceExhausted:
+		kind = IllegalStateFault
+		errCode = ILLEGALSTATE_ERRCODE_RATE_LIMITED
+	case codes.Unavailable:
+		kind = IllegalStateFault
+		errCode = ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE
+	case codes.DeadlineExceeded:
+		kind = IllegalStateFault
+		errCode = ILLEGALSTATE_ERRCODE_TIMED_OUT
+	case codes.Canceled:
+		kind = IllegalStateFault
+		errCode = ILLEGALSTATE_ERRCODE_CANCELLED
+	case codes.Unimplemented:
+		kind = NotImplementedFault
+	}
+	retryable := grpcStatus.Code() == codes.ResourceExhausted || grpcStatus.Code() == codes.Unavailable || grpcStatus.Code() == codes.DeadlineExceeded
+	var builder *FaultBuilder
+	switch grpcStatus.Code() {
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		builder = NewFaultBuilder(kind)
+	default:
+		builder = NewPublicFaultBuilder(kind)
+	}
+	return builder.
+		WithMessageTemplate("{"+grpcMessageLabel+"}").
+		WithErrorCode(errCode).
+		WithIsRetryable(retryable).
+		WithLabel(grpcCodeLabel, grpcStatus.Code().String()).
+		WithLabel(grpcMessageLabel, grpcStatus.Message())
+}
+
+// Returns a gRPC unary client interceptor - the counterpart of `UnaryServerInterceptor()`. If the call fails with a gRPC status error then it is turned
+// back into a Fault with `NewFaultFromGrpcStatus()` - so the calling code gets a rich Fault (kind, error codes, retryability) instead of a bare gRPC error.
+// The status error is attached as cause - so `status.Code()` / `status.FromError()` checks (and the status details) keep working on the Fault too.
+// Other errors are returned as they are.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			return nil
+		}
+		if grpcStatus, isStatus := status.FromError(err); isStatus && grpcStatus.Code() != codes.OK {
+			return newFaultBuilderFromGrpcStatus(grpcStatus).WithCause(err).Build()
+		}
+		return err
+	}
+}
+
+// Returns the transaction id from the incoming gRPC metadata - or empty string if there is none.
+func transactionIdFromIncomingContext(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, GRPC_TRANSACTIONID_METADATA_KEY); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+// Testing helpers you can use in your own test suites when working with `kt_errors.Fault`s.
+package kttest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"google.golang.org/grpc/codes"
+)
+
+// Fails the test immediately if the given error is not nil (the `kt_errors.NoFault` sentinel is accepted too).
+//
+// The difference compared to e.g. `require.NoError()` is that if the error is a `kt_errors.Fault` then the failure message contains the full `String()`
+// representation of it (including the cause chain, call stack, labels etc) instead of the terse `Error()` form. This makes failures much easier to diagnose.
+func RequireNoFault(t testing.TB, err error) {
+	t.Helper()
+	if err == nil {
+		return
+	}
+	isFault, fault := kt_errors.IsFault(err)
+	if isFault && kt_errors.IsNoFault(fault) {
+		return
+	}
+	if isFault {
+		t.Fatalf("expected no error but got a Fault: %s", fault.String())
+		return
+	}
+	t.Fatalf("expected no error but got: %s", err)
+}
+
+// What `AssertFault()` checks. Zero valued fields (empty `Kind`, no `Codes`, nil `Retryable` / `Public`, 0 `HttpStatus`) are not checked - so you
+// only need to set what your test cares about.
+type Expectations struct {
+	// The expected `GetKind()`
+	Kind kt_errors.FaultKind
+	// All of these error codes must be present - the Fault may have more codes though
+	Codes []string
+	// The expected `IsRetryable()`
+	Retryable *bool
+	// The expected `IsPublic()`
+	Public *bool
+	// The expected `GetHttpStatusCode()`
+	HttpStatus int
+}
+
+// Checks the given error against all set fields of the `Expectations` and reports every mismatch (not only the first one) - each with the full
+// `String()` representation of the Fault. Fails the test if the error is not a Fault at all (nil or the `kt_errors.NoFault` sentinel included).
+//
+// Returns true if all checks passed.
+func AssertFault(t testing.TB, err error, expected Expectations) bool {
+	t.Helper()
+	isFault, fault := kt_errors.IsFault(err)
+	if !isFault || kt_errors.IsNoFault(fault) {
+		t.Errorf("expected a Fault but got: %v", err)
+		return false
+	}
+	ok := true
+	if expected.Kind != "" && fault.GetKind() != expected.Kind {
+		t.Errorf("expected kind '%s' but got '%s' in Fault: %s", expected.Kind, fault.GetKind(), fault.String())
+		ok = false
+	}
+	for _, code := range expected.Codes {
+		if !fault.HasErrorCode(code) {
+			t.Errorf("expected error code '%s' but it is missing (codes: %s) in Fault: %s", code, fault.GetErrorCodesString(), fault.String())
+			ok = false
+		}
+	}
+	if expected.Retryable != nil && fault.IsRetryable() != *expected.Retryable {
+		t.Errorf("expected retryable=%t but got %t in Fault: %s", *expected.Retryable, fault.IsRetryable(), fault.String())
+		ok = false
+	}
+	if expected.Public != nil && fault.IsPublic() != *expected.Public {
+		t.Errorf("expected public=%t but got %t in Fault: %s", *expected.Public, fault.IsPublic(), fault.String())
+		ok = false
+	}
+	if expected.HttpStatus != 0 && fault.GetHttpStatusCode() != expected.HttpStatus {
+		t.Errorf("expected HTTP status %d but got %d in Fault: %s", expected.HttpStatus, fault.GetHttpStatusCode(), fault.String())
+		ok = false
+	}
+	return ok
+}
+
+// Tells if the given Fault maps to the expected HTTP status (see `kt_errors.GetHttpStatusCodeForFault()`) - e.g. "this endpoint returns 404 for missing".
+// If not then false is returned with a descriptive reason - which also tells if the mismatch is caused by the Fault being non-public (as non-public
+// Faults always map to 500). On match the reason is empty. Meant to be used e.g. as
+//
+//	ok, reason := kttest.ExpectHttpStatus(fault, 404)
+//	assert.True(t, ok, reason)
+func ExpectHttpStatus(fault kt_errors.Fault, status int) (bool, string) {
+	actual := kt_errors.GetHttpStatusCodeForFault(fault)
+	if actual == status {
+		return true, ""
+	}
+	return false, fmt.Sprintf("expected HTTP status %d but got %d%s", status, actual, mismatchDetails(fault))
+}
+
+// The gRPC counterpart of `ExpectHttpStatus()` - tells if the given Fault maps to the expected gRPC code (see `kt_errors.GetGrpcStatusCodeForFault()`).
+func ExpectGrpcCode(fault kt_errors.Fault, code codes.Code) (bool, string) {
+	actual := kt_errors.GetGrpcStatusCodeForFault(fault)
+	if actual == code {
+		return true, ""
+	}
+	return false, fmt.Sprintf("expected gRPC code %s but got %s%s", code, actual, mismatchDetails(fault))
+}
+
+// Explains the status mismatch of the Fault for `ExpectHttpStatus()` and `ExpectGrpcCode()`.
+func mismatchDetails(fault kt_errors.Fault) string {
+	if kt_errors.IsNoFault(fault) {
+		return " - there is no Fault"
+	}
+	if !fault.IsPublic() {
+		return fmt.Sprintf(" - the Fault is not public so its kind '%s' is not considered: %s", fault.GetKind(), fault.String())
+	}
+	return fmt.Sprintf(" for kind '%s' with codes %s: %s", fault.GetKind(), fault.GetErrorCodesString(), fault.String())
+}
@@ -0,0 +1,80 @@
+package kt_errors
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// The recycled Fault structs of the pooled fast path - see `NewPooledFaultBuilder()`
+var faultPool = sync.Pool{
+	New: func() any { return new(defaultFault) },
+}
+
+// Same as `NewFaultBuilder()` but the built Faults are taken from a pool - and you can give them back with `Release()` once you are done with them.
+// This is an optional fast path for hot paths only (e.g. validation heavy APIs emitting many Faults per second) to reduce the GC pressure.
+//
+// IMPORTANT! The lifetime contract is on you - and it is unsafe: once you `Release()` a Fault it might be handed out again by a later `Build()`, so
+// after releasing you (and anyone you passed the Fault to!) must not touch it anymore. Only release a Fault once it is fully consumed (serialized,
+// logged etc) and you are sure no reference to it survives - e.g. it was not attached as a cause to another error, not stored anywhere. If in doubt simply
+// do not release - a never released pooled Fault is just garbage collected like any other. (A reference surviving the release does not see the data
+// of the next owner though - see `Release()`.)
+func NewPooledFaultBuilder(errType FaultKind) *FaultBuilder {
+	builder := NewFaultBuilder(errType)
+	builder.pooled = true
+	return builder
+}
+
+// The handle `NewPooledFaultBuilder()` hands out - this is what the caller holds, the pooled struct behind it is recycled. The handle carries the
+// generation of the struct it was built with - so a stale handle (released already, while the struct went on to a new owner) can not release it again.
+// And on release the handle is detached from the struct - so a stale handle can not read the struct of the new owner either.
+type pooledFault struct {
+	// the Fault methods are served by this - it is set to nil on release
+	*defaultFault
+	// the struct and its generation when it was handed out - never changes, so `Release()` can use it without racing with the detach
+	pooled     *defaultFault
+	generation uint32
+}
+
+// Gives back a Fault built by a `NewPooledFaultBuilder()` to the pool - please read the lifetime contract there!
+//
+// Returns true if the Fault was really given back. Releasing a not pooled Fault (or Nil, `NoFault`, a copy made e.g. by `WithKindOverride()`) has no
+// effect, and neither has releasing the same Fault twice (even concurrently) or releasing a stale reference after the struct was handed out again: a
+// generation guard makes sure the struct gets into the pool only once per use - otherwise two later `Build()`s could hand out the very same Fault.
+//
+// Once released the Fault is detached from the pooled struct: using it afterwards behaves like using a nil Fault (empty values - or a panic if
+// `SetPanicOnNilFault()` is on) instead of reading or mutating the data of the next owner. Please note: this is not a synchronization - using the Fault
+// concurrently with releasing it is still a violation of the lifetime contract.
+func Release(f Fault) bool {
+	handle, ok := f.(*pooledFault)
+	if !ok || handle == nil {
+		return false
+	}
+	// the generation is only touched atomically - a concurrent or stale `Release()` loses and returns false
+	if !atomic.CompareAndSwapUint32(&handle.pooled.poolGeneration, handle.generation, handle.generation+1) {
+		return false
+	}
+	handle.defaultFault = nil
+	faultPool.Put(handle.pooled)
+	return true
+}
+
+// Takes a Fault struct from the pool and fills it with the given (built) Fault. Everything left from the previous use is dropped - only the labels map
+// is kept for recycling. Returns a new handle to it - see `pooledFault`.
+func takePooledFault(built defaultFault) *pooledFault {
+	fault := faultPool.Get().(*defaultFault)
+	labels := fault.Labels
+	clear(labels)
+	// the generation goes on from where it was left - it becomes odd again (in use)
+	built.poolGeneration = atomic.LoadUint32(&fault.poolGeneration) + 1
+	if built.Labels != nil {
+		if labels == nil {
+			labels = make(map[string]any, len(built.Labels))
+		}
+		maps.Copy(labels, built.Labels)
+		built.Labels = labels
+	}
+	// we own the struct exclusively here - it is not handed out to anyone (unless the lifetime contract was violated)
+	*fault = built
+	return &pooledFault{defaultFault: fault, pooled: fault, generation: built.poolGeneration}
+}
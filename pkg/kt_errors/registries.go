@@ -0,0 +1,462 @@
+package kt_errors
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+)
+
+var (
+	registryLock sync.RWMutex
+
+	// The known Fault kinds - the built-in ones are always there, custom ones can be added with `RegisterFaultKinds()`
+	registeredKinds = []FaultKind{
+		RuntimeFault,
+		IllegalStateFault,
+		NotImplementedFault,
+		ValidationFault,
+		ConstraintViolationFault,
+		ResourceNotFoundFault,
+		AuthenticationFault,
+		AuthorizationFault,
+	}
+
+	// The known audiences - `MSGAUDIENCE_USER` is always there, custom ones can be added with `RegisterAudience()`
+	registeredAudiences = []string{
+		MSGAUDIENCE_USER,
+	}
+	// See `SetStrictAudiences()`
+	strictAudiences = false
+
+	// See `SetMaxLabelCount()` and `SetMaxLabelValueLength()` - 0 means unlimited
+	maxLabelCount       = 0
+	maxLabelValueLength = 0
+
+	// See `SetPanicOnNilFault()`
+	panicOnNilFault = false
+
+	// See `SetNaturalJSONFieldNames()` - the zero value keeps all the default keys
+	naturalJSONFieldNames NaturalJSONFieldNames
+)
+
+// The retryability policy of the Fault kinds - tells if a Fault of the kind is allowed to be retryable at all. Kinds not listed here are allowed.
+// Can be adjusted with `SetKindRetryabilityPolicy()`.
+var kindRetryabilityPolicy = map[FaultKind]bool{
+	// these are inheritedly not retryable - retrying the same thing will lead to the same result
+	NotImplementedFault:   false,
+	ValidationFault:       false,
+	ResourceNotFoundFault: false,
+}
+
+// You can override if Faults of the given kind are allowed to be retryable at all. This policy is consulted by both the `FaultBuilder.WithIsRetryable()`
+// and `FaultBuilder.Build()` methods (and everywhere else the retryability rules are evaluated).
+//
+// By default all kinds are allowed except `NotImplementedFault`, `ValidationFault` and `ResourceNotFoundFault` - but e.g. if you work with an eventually
+// consistent store you might want to make `ResourceNotFoundFault` retryable.
+// Please note: the error code based rules (e.g. `AuthorizationFault` with `AUTHORIZATION_NO_PERMISSION` code is never retryable) are still applied on top
+// of this policy.
+func SetKindRetryabilityPolicy(kind FaultKind, allowed bool) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	kindRetryabilityPolicy[kind] = allowed
+}
+
+// Tells if Faults of the given kind are allowed to be retryable - see `SetKindRetryabilityPolicy()`.
+func IsKindRetryabilityAllowed(kind FaultKind) bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	allowed, found := kindRetryabilityPolicy[kind]
+	return !found || allowed
+}
+
+// The default generator of the error references - generates references like "ERR-7F3A9C".
+func DefaultReferenceGenerator() string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return "ERR-" + strings.ToUpper(hex.EncodeToString(b))
+}
+
+var referenceGenerator func() string = DefaultReferenceGenerator
+
+// The default generator of the error instance ids - generates random (version 4) UUIDs like "3f2b8c1e-9a4d-4e6f-8b2a-1c5d7e9f0a3b".
+func DefaultInstanceIdGenerator() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	// version 4, variant RFC 4122
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+var instanceIdGenerator func() string = DefaultInstanceIdGenerator
+
+const (
+	defaultConversionMessageTemplateWithTxId    = "Error occurred during processing, details are logged with transactionId '{transactionId}'"
+	defaultConversionMessageTemplateWithoutTxId = "Error occurred during processing, details are logged"
+)
+
+var (
+	conversionMessageTemplateWithTxId    = defaultConversionMessageTemplateWithTxId
+	conversionMessageTemplateWithoutTxId = defaultConversionMessageTemplateWithoutTxId
+)
+
+// You can override the generic message template `NewPublicFaultFromAnyError()` is using for the converted public Faults - e.g. to localize it.
+//
+// The `withTxId` variant is used if transactionId was given to the conversion - in this case the transactionId is available as "transactionId" label, so
+// the template must contain the "{transactionId}" placeholder! If it does not then it is rejected: a warning is logged (with the default logger) and the
+// built-in default is used instead. The `withoutTxId` variant is used otherwise.
+// If you pass empty string for any of them then the built-in default is restored for that one.
+func SetDefaultConversionMessageTemplate(withTxId string, withoutTxId string) {
+	if withTxId != "" && !strings.Contains(withTxId, "{transactionId}") {
+		getDefaultLogger().Warn("Conversion message template '%s' does not contain the {transactionId} placeholder - the built-in default is used instead", withTxId)
+		withTxId = ""
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if withTxId == "" {
+		withTxId = defaultConversionMessageTemplateWithTxId
+	}
+	if withoutTxId == "" {
+		withoutTxId = defaultConversionMessageTemplateWithoutTxId
+	}
+	conversionMessageTemplateWithTxId = withTxId
+	conversionMessageTemplateWithoutTxId = withoutTxId
+}
+
+func getConversionMessageTemplates() (withTxId string, withoutTxId string) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return conversionMessageTemplateWithTxId, conversionMessageTemplateWithoutTxId
+}
+
+var defaultRenderTemplate = builtInRenderTemplate
+
+// You can change the template `fault.Render()` uses if no template is passed to it. If you pass Nil then the built-in template is restored.
+func SetDefaultRenderTemplate(tmpl *template.Template) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if tmpl == nil {
+		tmpl = builtInRenderTemplate
+	}
+	defaultRenderTemplate = tmpl
+}
+
+// Returns the template `fault.Render()` uses if no template is passed to it - see `SetDefaultRenderTemplate()`.
+func GetDefaultRenderTemplate() *template.Template {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return defaultRenderTemplate
+}
+
+// You can plug in your own generator of the error references (see `fault.GetReference()`). If you pass Nil then the `DefaultReferenceGenerator()` is
+// restored.
+func SetReferenceGenerator(generator func() string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if generator == nil {
+		generator = DefaultReferenceGenerator
+	}
+	referenceGenerator = generator
+}
+
+func generateReference() string {
+	registryLock.RLock()
+	generator := referenceGenerator
+	registryLock.RUnlock()
+	return generator()
+}
+
+// You can plug in your own generator of the error instance ids (see `fault.GetInstanceId()`) - e.g. for deterministic tests. If you pass Nil then the
+// `DefaultInstanceIdGenerator()` is restored.
+func SetInstanceIdGenerator(generator func() string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if generator == nil {
+		generator = DefaultInstanceIdGenerator
+	}
+	instanceIdGenerator = generator
+}
+
+func generateInstanceId() string {
+	registryLock.RLock()
+	generator := instanceIdGenerator
+	registryLock.RUnlock()
+	return generator()
+}
+
+// You can register your own custom `FaultKind`s with this method - so they become known kinds. Registering a kind which is already known has no effect.
+func RegisterFaultKinds(kinds ...FaultKind) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	for _, kind := range kinds {
+		if kind != "" && !slices.Contains(registeredKinds, kind) {
+			registeredKinds = append(registeredKinds, kind)
+		}
+	}
+}
+
+// Returns all known `FaultKind`s - the built-in ones plus the ones registered with `RegisterFaultKinds()`.
+// **Note:** This always makes and returns a copy so use it accordingly! If possible use `IsRegisteredFaultKind()` instead.
+func GetRegisteredFaultKinds() []FaultKind {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return slices.Clone(registeredKinds)
+}
+
+// Tells if the given `FaultKind` is known - built-in or registered with `RegisterFaultKinds()`.
+func IsRegisteredFaultKind(kind FaultKind) bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return slices.Contains(registeredKinds, kind)
+}
+
+// You can register your own audiences (see `FaultBuilder.WithMessageTemplateForAudience()`) with this method - so they become known audiences. Registering
+// an audience which is already known has no effect. `MSGAUDIENCE_USER` is always known.
+func RegisterAudience(name string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if name != "" && !slices.Contains(registeredAudiences, name) {
+		registeredAudiences = append(registeredAudiences, name)
+	}
+}
+
+// Tells if the given audience is known - `MSGAUDIENCE_USER` or registered with `RegisterAudience()`.
+func IsRegisteredAudience(name string) bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return slices.Contains(registeredAudiences, name)
+}
+
+// Switches the strict audiences mode on/off (off by default). In strict mode
+//   - the builder methods setting audience message templates log a warning (with the default logger) if an unregistered audience is used
+//   - `FaultBuilder.BuildStrict()` rejects the Fault if it has message templates for unregistered audiences
+//
+// This helps to keep the audience naming disciplined across a large codebase. See `RegisterAudience()`!
+func SetStrictAudiences(strict bool) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	strictAudiences = strict
+}
+
+// Tells if the strict audiences mode is on - see `SetStrictAudiences()`.
+func IsStrictAudiences() bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return strictAudiences
+}
+
+// The methods of a nil Fault (a nil `*defaultFault` used as `Fault` or `error`) are lenient by default - they simply return empty values. This might
+// hide bugs though. If you turn this on (e.g. in tests or in dev environments) then these methods panic with a clear message instead. Off by default -
+// production should keep the lenient behavior.
+func SetPanicOnNilFault(enabled bool) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	panicOnNilFault = enabled
+}
+
+// Tells if the methods of a nil Fault panic - see `SetPanicOnNilFault()`.
+func IsPanicOnNilFault() bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return panicOnNilFault
+}
+
+// Overrides of the JSON keys used in the natural form (see `ToNaturalJSON()`). Every field you leave empty keeps its default key (shown in the
+// comments) - so the zero value means the natural form is exactly what it always was.
+type NaturalJSONFieldNames struct {
+	// default: "kind"
+	Kind string
+	// default: "message"
+	Message string
+	// default: "isRetryable"
+	Retryable string
+	// default: "errorCodes"
+	ErrorCodes string
+	// default: "labels"
+	Labels string
+	// default: "reference"
+	Reference string
+	// default: "helpUrl"
+	HelpUrl string
+	// default: "cause"
+	Cause string
+}
+
+// If your clients already have a contract with different JSON keys (e.g. "error_code" instead of "errorCodes" or "retryable" instead of "isRetryable")
+// you can override the keys the natural form is using - so you do not need a translating proxy layer. This affects everything which produces the natural
+// form (`ToNaturalJSON()`, `WriteNaturalJSON()`, `ToWarningJSON()`, `json.Marshal()` of a Fault) and `PublicFault` unmarshals the renamed keys too.
+// Passing the zero value restores the default keys.
+func SetNaturalJSONFieldNames(names NaturalJSONFieldNames) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	naturalJSONFieldNames = names
+}
+
+// Returns the currently used overrides of the natural form JSON keys - see `SetNaturalJSONFieldNames()`.
+func GetNaturalJSONFieldNames() NaturalJSONFieldNames {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return naturalJSONFieldNames
+}
+
+// Returns the default key -> overridden key mapping of the natural form (see `SetNaturalJSONFieldNames()`) - nil if nothing is overridden.
+func getNaturalJSONKeyRenames() map[string]string {
+	names := GetNaturalJSONFieldNames()
+	var renames map[string]string
+	for defaultKey, overriddenKey := range map[string]string{
+		"kind":        names.Kind,
+		"message":     names.Message,
+		"isRetryable": names.Retryable,
+		"errorCodes":  names.ErrorCodes,
+		"labels":      names.Labels,
+		"reference":   names.Reference,
+		"helpUrl":     names.HelpUrl,
+		"cause":       names.Cause,
+	} {
+		if overriddenKey != "" && overriddenKey != defaultKey {
+			if renames == nil {
+				renames = make(map[string]string)
+			}
+			renames[defaultKey] = overriddenKey
+		}
+	}
+	return renames
+}
+
+// To protect the downstream log storage you can cap how many labels a Fault can carry. Once a Fault has this many labels, further labels are dropped
+// (with a debug log) - replacing the value of an existing label is still possible. Passing 0 (the default) means unlimited.
+func SetMaxLabelCount(maxCount int) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	maxLabelCount = max(maxCount, 0)
+}
+
+// To protect the downstream log storage you can cap how long (in characters) the string label values can be. Longer values are truncated and get
+// a "..." marker at the end. Passing 0 (the default) means unlimited.
+func SetMaxLabelValueLength(maxLength int) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	maxLabelValueLength = max(maxLength, 0)
+}
+
+func getLabelLimits() (maxCount int, maxValueLength int) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return maxLabelCount, maxLabelValueLength
+}
+
+var (
+	// See `SetFaultStatsEnabled()` - we store the negated flag so the zero value means enabled
+	faultStatsDisabled atomic.Bool
+	// guards the map only - the counters themselves are atomic
+	faultStatsLock sync.RWMutex
+	faultStats     = make(map[FaultKind]*atomic.Int64)
+)
+
+// Switches the process-global Fault counters (see `FaultStats()`) on/off - they are on by default. For perf-sensitive deployments you can opt out.
+func SetFaultStatsEnabled(enabled bool) {
+	faultStatsDisabled.Store(!enabled)
+}
+
+// Tells if the Fault counters are on - see `SetFaultStatsEnabled()`.
+func IsFaultStatsEnabled() bool {
+	return !faultStatsDisabled.Load()
+}
+
+// Returns how many Faults were built (see `FaultBuilder.Build()`) per kind since the start of the process (or since the last `ResetFaultStats()`).
+// This is a lightweight introspection - not a replacement of a real metrics backend. See also `SetFaultStatsEnabled()`.
+//
+// Only the Faults your code builds are counted (including the ones built by the constructors like `NewValidationFault()` or from the catalog). The Faults
+// the library derives from already existing errors - the public conversion (`NewPublicFaultFromAnyError()`, `Sanitize()` etc), merging
+// (`NewPublicFaultFromErrors()`) or unmarshaling a `PublicFault` - are not counted, so one error is not counted twice.
+//
+// The returned map is a snapshot copy.
+func FaultStats() map[FaultKind]int64 {
+	faultStatsLock.RLock()
+	defer faultStatsLock.RUnlock()
+	ret := make(map[FaultKind]int64, len(faultStats))
+	for kind, counter := range faultStats {
+		ret[kind] = counter.Load()
+	}
+	return ret
+}
+
+// Zeroes the Fault counters - see `FaultStats()`.
+func ResetFaultStats() {
+	faultStatsLock.Lock()
+	defer faultStatsLock.Unlock()
+	faultStats = make(map[FaultKind]*atomic.Int64)
+}
+
+// Increments the counter of the given kind - unless the stats are disabled.
+func countBuiltFault(kind FaultKind) {
+	if faultStatsDisabled.Load() {
+		return
+	}
+	faultStatsLock.RLock()
+	counter, found := faultStats[kind]
+	faultStatsLock.RUnlock()
+	if !found {
+		faultStatsLock.Lock()
+		counter, found = faultStats[kind]
+		if !found {
+			counter = new(atomic.Int64)
+			faultStats[kind] = counter
+		}
+		faultStatsLock.Unlock()
+	}
+	counter.Add(1)
+}
+
+// Returns a snapshot of all the registered mappings and package level configurations - useful e.g. to log it at startup so you can see how the
+// library is configured at runtime.
+//
+// The returned map is a copy - you can not change the configuration through it.
+func DumpRegistries() map[string]any {
+	kinds := GetRegisteredFaultKinds()
+	kindHttpStatus := make(map[FaultKind]int, len(kinds))
+	kindGrpcStatus := make(map[FaultKind]string, len(kinds))
+	kindRetryable := make(map[FaultKind]bool, len(kinds))
+	for _, kind := range kinds {
+		// not built with the builder - this should not show up in `FaultStats()`
+		fault := newInitializedFault(kind)
+		fault.public = true
+		kindHttpStatus[kind] = fault.GetHttpStatusCode()
+		kindGrpcStatus[kind] = fault.GetGrpcStatusCode().String()
+		kindRetryable[kind] = IsKindRetryabilityAllowed(kind)
+	}
+
+	withTxId, withoutTxId := getConversionMessageTemplates()
+
+	registryLock.RLock()
+	audiences := slices.Clone(registeredAudiences)
+	registryLock.RUnlock()
+	maxCount, maxValueLength := getLabelLimits()
+
+	return map[string]any{
+		"faultKinds":      kinds,
+		"kindHttpStatus":  kindHttpStatus,
+		"kindGrpcStatus":  kindGrpcStatus,
+		"kindRetryable":   kindRetryable,
+		"audiences":       audiences,
+		"strictAudiences": IsStrictAudiences(),
+		"panicOnNilFault": IsPanicOnNilFault(),
+		"labelLimits": map[string]int{
+			"maxLabelCount":       maxCount,
+			"maxLabelValueLength": maxValueLength,
+		},
+		"conversionMessageTemplates": map[string]string{
+			"withTxId":    withTxId,
+			"withoutTxId": withoutTxId,
+		},
+		// the template itself can not be dumped - its name tells which one is in use (the built-in one is "fault")
+		"defaultRenderTemplate": GetDefaultRenderTemplate().Name(),
+		"naturalJSONFieldNames": GetNaturalJSONFieldNames(),
+		"faultStatsEnabled":     IsFaultStatsEnabled(),
+	}
+}
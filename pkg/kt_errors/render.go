@@ -0,0 +1,57 @@
+package kt_errors
+
+import (
+	"strings"
+	"text/template"
+)
+
+// The data a Fault exposes to the template in `fault.Render()`.
+type FaultRenderData struct {
+	Kind FaultKind
+	// The resolved message for public Faults - the unresolved message template for non-public Faults (so the label values can not leak through it)
+	Message   string
+	Codes     []string
+	Retryable bool
+	Source    string
+	// For non-public Faults the values are replaced with "[REDACTED]" - only the keys are kept
+	Labels map[string]any
+}
+
+// The built-in template `fault.Render()` uses if you do not pass one (and did not change it with `SetDefaultRenderTemplate()`) - renders log lines like
+// "[illegal_state] config_error,other_code: the message".
+var builtInRenderTemplate = template.Must(template.New("fault").Parse(
+	`[{{.Kind}}]{{range $i, $code := .Codes}}{{if $i}},{{else}} {{end}}{{$code}}{{end}}{{if .Codes}}:{{end}} {{.Message}}`,
+))
+
+func (fault *defaultFault) Render(tmpl *template.Template) (string, error) {
+	if tmpl == nil {
+		tmpl = GetDefaultRenderTemplate()
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, fault.renderData()); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// Returns the data `Render()` exposes to the template - honoring the public flag.
+func (fault *defaultFault) renderData() FaultRenderData {
+	if fault.isNoFault() {
+		return FaultRenderData{Codes: make([]string, 0), Labels: make(map[string]any)}
+	}
+	data := FaultRenderData{
+		Kind:      fault.Kind,
+		Message:   fault.GetMessage(),
+		Codes:     fault.GetErrorCodes(),
+		Retryable: fault.Retryable,
+		Source:    fault.GetSource(),
+		Labels:    fault.GetLabels(),
+	}
+	if !fault.public {
+		data.Message = fault.MessageTemplate
+		for key := range data.Labels {
+			data.Labels[key] = redactedValue
+		}
+	}
+	return data
+}
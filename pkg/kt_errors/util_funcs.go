@@ -1,7 +1,13 @@
 package kt_errors
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
 	"slices"
+	"strings"
 
 	"github.com/keytiles/lib-logging-golang/v2/pkg/kt_logging"
 	"github.com/keytiles/lib-utils-golang/pkg/kt_utils"
@@ -21,8 +27,11 @@ func IsFault(err error) (bool, Fault) {
 }
 
 const (
-	logLabelsOption        int = 1
-	whitelistedKindsOption int = 2
+	logLabelsOption         int = 1
+	whitelistedKindsOption  int = 2
+	preserveMessageOption   int = 3
+	attachFingerprintOption int = 4
+	logConfigOption         int = 5
 )
 
 // Can be used as possible option passed into the conversion. Please see methods `OptionXXX()` for supported options!
@@ -31,6 +40,7 @@ type ConversionOption interface {
 	getLogLabels() []kt_logging.Label
 	getKinds() []FaultKind
 	getFlag() bool
+	getLogLevel() kt_logging.LogLevel
 }
 
 // Conversion option to carry extra log labels.
@@ -50,6 +60,9 @@ func (o optionLogLabels) getKinds() []FaultKind {
 func (o optionLogLabels) getFlag() bool {
 	return false
 }
+func (o optionLogLabels) getLogLevel() kt_logging.LogLevel {
+	return kt_logging.NoneLevel
+}
 
 type optionWhiteListedKinds struct {
 	kinds             []FaultKind
@@ -68,6 +81,66 @@ func (o optionWhiteListedKinds) getKinds() []FaultKind {
 func (o optionWhiteListedKinds) getFlag() bool {
 	return o.inheritErrorCodes
 }
+func (o optionWhiteListedKinds) getLogLevel() kt_logging.LogLevel {
+	return kt_logging.NoneLevel
+}
+
+type optionPreserveMessage struct{}
+
+func (o optionPreserveMessage) getOptionId() int {
+	return preserveMessageOption
+}
+func (o optionPreserveMessage) getLogLabels() []kt_logging.Label {
+	return nil
+}
+func (o optionPreserveMessage) getKinds() []FaultKind {
+	return nil
+}
+func (o optionPreserveMessage) getFlag() bool {
+	return true
+}
+func (o optionPreserveMessage) getLogLevel() kt_logging.LogLevel {
+	return kt_logging.NoneLevel
+}
+
+type optionAttachFingerprint struct{}
+
+func (o optionAttachFingerprint) getOptionId() int {
+	return attachFingerprintOption
+}
+func (o optionAttachFingerprint) getLogLabels() []kt_logging.Label {
+	return nil
+}
+func (o optionAttachFingerprint) getKinds() []FaultKind {
+	return nil
+}
+func (o optionAttachFingerprint) getFlag() bool {
+	return true
+}
+func (o optionAttachFingerprint) getLogLevel() kt_logging.LogLevel {
+	return kt_logging.NoneLevel
+}
+
+type optionLogConfig struct {
+	level    kt_logging.LogLevel
+	suppress bool
+}
+
+func (o optionLogConfig) getOptionId() int {
+	return logConfigOption
+}
+func (o optionLogConfig) getLogLabels() []kt_logging.Label {
+	return nil
+}
+func (o optionLogConfig) getKinds() []FaultKind {
+	return nil
+}
+func (o optionLogConfig) getFlag() bool {
+	return o.suppress
+}
+func (o optionLogConfig) getLogLevel() kt_logging.LogLevel {
+	return o.level
+}
 
 // You can pass in labels with this option which will decorate the log event.
 //
@@ -91,6 +164,36 @@ func OptionWhitelistedFaultKinds(inheritErrorCodes bool, kinds ...FaultKind) Con
 	}
 }
 
+// By default the message of the original `Fault` is considered unsafe and is replaced during the conversion. With this option - combined with
+// `OptionWhitelistedFaultKinds()` - the converted public `Fault` keeps the message template of the original if its kind was whitelisted. (Labels needed by
+// the message are kept following the same rules as for audience messages.) If the original has no message template then the generic message is used.
+//
+// Has no effect on non-whitelisted kinds - their message is still hidden.
+func OptionPreserveMessageForWhitelisted() ConversionOption {
+	return optionPreserveMessage{}
+}
+
+// With this option the fingerprint of the original error (see `fault.GetFingerprint()`) is attached both to the converted public `Fault` and to the log
+// as "fingerprint" label. This gives users a short code to quote to support which correlates the generic message with the internal error.
+//
+// For non-Fault errors the fingerprint is derived from the error type and the error text - where every word containing a digit (numbers, ids, UUIDs,
+// IP addresses etc) is replaced with "#" first. This keeps the fingerprint stable in most cases, but if the error text carries other variable parts
+// (e.g. names) then the fingerprint varies too - wrap such errors into Faults to get a really stable one.
+func OptionAttachFingerprint() ConversionOption {
+	return optionAttachFingerprint{}
+}
+
+// You can control with this option how (or whether) `NewPublicFaultFromAnyError()` logs the original error. By default it is logged on warning level.
+// With `level` you can change this level (an unknown level falls back to warning), and with `suppress=true` nothing is logged at all - the conversion
+// still happens though. Useful to reduce the noise for expected conversions. This applies to all the logs of the conversion (including the notices about
+// the carried forward / dropped labels).
+func OptionLogConfig(level kt_logging.LogLevel, suppress bool) ConversionOption {
+	return optionLogConfig{
+		level:    level,
+		suppress: suppress,
+	}
+}
+
 // Turns any error into a public Fault instance.
 //
 // In case the error is already isPublic=true `Fault` then it is returned as it is. Piece of cake :-)
@@ -99,15 +202,23 @@ func OptionWhitelistedFaultKinds(inheritErrorCodes bool, kinds ...FaultKind) Con
 // implementation details (e.g. we use S3 buckets which failed - the message can reveal this fact we use S3 buckets - not good)
 //
 // Therefore what happens is that
-//   - The method will log the original error. (This is why we need a `loggerToUse` param - see below)
-//   - Then construct an isPublic=true `Fault` with generic safe message like "something has happened - details in the log".
+//   - The method will log the original error. (This is why we need a `loggerToUse` param - see below. See `OptionLogConfig()` to change the level or
+//     to suppress it.)
+//   - Then construct an isPublic=true `Fault` with generic safe message like "something has happened - details in the log". (You can change this message
+//     with `SetDefaultConversionMessageTemplate()`.)
 //   - Adds error code `ERRCODE_INTERNAL_ERROR`. (If you used `OptionWhitelistedFaultKinds()` that can fine grain this - see description!)
 //   - Sets the `cause` of the error to the original error.
+//   - The reference of the constructed public error (see `fault.GetReference()`) is added to the log as "errorReference" label.
+//   - The instance id of the constructed public error (see `fault.GetInstanceId()`) is added both to the log and to the public error as "instanceId"
+//     label - so the generic error the client gets and the internal log share the id.
 //
 // In case the original error is isPublic=false `Fault` then we can keep some data from the original error for sure - but with care!
 // Retry behavior is alwqys inherited. However the message of the error is still considered unsafe. But if it carries message for audience `MSGAUDIENCE_USER`
 // then that one turns into the main message of the converted public error. All labels removed but the ones used in any `messageTemplatesByAudience`. And
 // original error codes are also removed. They can potentially again leak out internal implementation details.
+// Labels needed by the messages might still carry sensitive values though. So if the original `Fault` has labels explicitly marked public (see builder method
+// `WithPublicLabels()`) then only those are kept, the rest is dropped. If there are no such markers at all then all needed labels are kept but this fact is
+// logged.
 //
 // Arguments:
 //   - 'original': The error you want to turn into a public `Fault`.
@@ -129,93 +240,275 @@ func NewPublicFaultFromAnyError(original error, transactionId string, loggerToUs
 		}
 	}
 
-	var logLabels []kt_logging.Label
+	conversion := convertToPublicFault(original, transactionId, options...)
+	// the reference of the public Fault also goes into the log - so support can map them
+	reference := generateReference()
+	conversion.builder.WithReference(reference)
+	// and so does the instance id - carried as label in the public Fault too, so the client can see it
+	instanceId := conversion.builder.fault.InstanceId
+	conversion.builder.WithLabel("instanceId", instanceId)
+	if conversion.fingerprint != "" {
+		conversion.logLabels = append(slices.Clone(conversion.logLabels), kt_logging.StringLabel("fingerprint", conversion.fingerprint))
+	}
+
+	if conversion.suppressLog {
+		return conversion.builder.Build()
+	}
+
+	// make sure we have a logger - we will need it
+	logger := loggerToUse
+	if logger == nil {
+		logger = getDefaultLogger()
+	}
+	logEvent := logger.WithLabels(conversion.logLabels).
+		WithLabel(kt_logging.StringLabel("errorReference", reference)).
+		WithLabel(kt_logging.StringLabel("instanceId", instanceId))
+
+	// is transactionId in labels?
+	if transactionId != "" && !slices.ContainsFunc(conversion.logLabels, func(item kt_logging.Label) bool { return item.GetStringValue() == transactionId }) {
+		// let's enforce we will really decorate the log event with the transaction id!
+		logEvent = logEvent.WithLabel(kt_logging.StringLabel("trId", transactionId))
+	}
+	if isFault {
+		logOnLevel(logEvent, conversion.logLevel,
+			"Unsafe error captured which we turn into a public Fault (kindKept: %t, inheritErrorCodes: %t) - hiding unsafe details. Orig error was: %s",
+			conversion.kindWasKept, conversion.inheritErrorCodes, kt_utils.VarPrinter{TheVar: fault},
+		)
+		if len(conversion.unmarkedLabelKeys) > 0 {
+			logOnLevel(logEvent, conversion.logLevel,
+				"Labels %v were carried forward into the public Fault but none of them was marked public (see `WithPublicLabels()`)", conversion.unmarkedLabelKeys,
+			)
+		}
+		if len(conversion.droppedLabelKeys) > 0 {
+			logOnLevel(logEvent, conversion.logLevel,
+				"Labels %v were dropped from the public Fault as they were not marked public (see `WithPublicLabels()`)", conversion.droppedLabelKeys,
+			)
+		}
+	} else {
+		logOnLevel(logEvent, conversion.logLevel,
+			"Unsafe error captured which we turn into a public Fault - hiding unsafe details. Orig error was: %s",
+			kt_utils.VarPrinter{TheVar: original},
+		)
+	}
+
+	return conversion.builder.Build()
+}
+
+// Fires the log event on the given level - `kt_logging.NoneLevel` means no logging. An unknown level falls back to warning - so a mistake in the
+// configuration does not swallow the log.
+func logOnLevel(logEvent kt_logging.LogEvent, level kt_logging.LogLevel, message string, messageParams ...any) {
+	switch level {
+	case kt_logging.NoneLevel:
+		// nothing to do
+	case kt_logging.ErrorLevel:
+		logEvent.Error(message, messageParams...)
+	case kt_logging.WarningLevel:
+		logEvent.Warn(message, messageParams...)
+	case kt_logging.InfoLevel:
+		logEvent.Info(message, messageParams...)
+	case kt_logging.DebugLevel:
+		logEvent.Debug(message, messageParams...)
+	default:
+		logEvent.Warn(message, messageParams...)
+	}
+}
+
+// Turns the given Fault into a client-safe (public) Fault - without any side effects. This is performing the same redaction / conversion logic as
+// `NewPublicFaultFromAnyError()` (see the description there!) but without logging and transaction-id machinery. Useful if you just need a sanitized copy for
+// a non-error path - e.g. previewing what a client would see.
+//
+// If the Fault is already public then it is returned as it is. In case of Nil, Nil is returned.
+func Sanitize(fault Fault, options ...ConversionOption) Fault {
+	if fault == nil {
+		return nil
+	}
+	if fault.IsPublic() {
+		return fault
+	}
+	return convertToPublicFault(fault, "", options...).builder.Build()
+}
+
+// Matches the words containing a digit - these are very likely variable parts (numbers, ids, UUIDs, IP addresses etc) of an error text
+var variableWordsPattern = regexp.MustCompile(`[\w.:-]*\d[\w.:-]*`)
+
+// The outcome of the conversion logic - the builder of the public Fault and the info we need to log.
+type publicFaultConversion struct {
+	builder           *FaultBuilder
+	logLabels         []kt_logging.Label
+	kindWasKept       bool
+	inheritErrorCodes bool
+	unmarkedLabelKeys []string
+	droppedLabelKeys  []string
+	// only with `OptionAttachFingerprint()`
+	fingerprint string
+	// see `OptionLogConfig()`
+	logLevel    kt_logging.LogLevel
+	suppressLog bool
+}
+
+// The conversion logic of `NewPublicFaultFromAnyError()` and `Sanitize()` - without side effects.
+func convertToPublicFault(original error, transactionId string, options ...ConversionOption) (conversion publicFaultConversion) {
+	isFault, fault := IsFault(original)
+	conversion.logLevel = kt_logging.WarningLevel
+
 	var safeKinds []FaultKind
-	kindWasKept := false
-	inheritErrorCodes := false
+	preserveMessage := false
 	for _, opt := range options {
 		if opt.getOptionId() == logLabelsOption {
-			logLabels = opt.getLogLabels()
+			conversion.logLabels = opt.getLogLabels()
 		} else if opt.getOptionId() == whitelistedKindsOption {
 			safeKinds = opt.getKinds()
-			inheritErrorCodes = opt.getFlag()
+			conversion.inheritErrorCodes = opt.getFlag()
+		} else if opt.getOptionId() == preserveMessageOption {
+			preserveMessage = opt.getFlag()
+		} else if opt.getOptionId() == logConfigOption {
+			conversion.logLevel = opt.getLogLevel()
+			conversion.suppressLog = opt.getFlag()
+		} else if opt.getOptionId() == attachFingerprintOption && opt.getFlag() {
+			if isFault {
+				conversion.fingerprint = fault.GetFingerprint()
+			} else {
+				conversion.fingerprint = fingerprintOf(fmt.Sprintf("%T", original), variableWordsPattern.ReplaceAllString(original.Error(), "#"))
+			}
 		}
 	}
 
 	kind := RuntimeFault
 	if isFault && slices.Contains(safeKinds, fault.GetKind()) {
 		kind = fault.GetKind()
-		kindWasKept = true
+		conversion.kindWasKept = true
 	}
 	builder := NewPublicFaultBuilder(kind).
 		WithCause(original)
+	// the conversion is not a new error - just another form of the original one
+	builder.uncounted = true
+	conversion.builder = builder
+
+	if conversion.fingerprint != "" {
+		builder.WithLabel("fingerprint", conversion.fingerprint)
+	}
 
 	// If we did not keep the original kind mark it as INTERNAL error
-	if !kindWasKept {
+	if !conversion.kindWasKept {
 		builder.WithErrorCodes(ERRCODE_INTERNAL_ERROR)
 	}
-	if inheritErrorCodes {
+	if isFault && conversion.inheritErrorCodes {
 		builder.WithErrorCodes(fault.GetErrorCodes()...)
 	}
 
 	// let's set a default message
+	msgTemplateWithTxId, msgTemplateWithoutTxId := getConversionMessageTemplates()
 	if transactionId != "" {
-		builder.WithMessageTemplate("Error occured during processing, details are logged with transactionId '{transactionId}'").
+		builder.WithMessageTemplate(msgTemplateWithTxId).
 			WithLabel("transactionId", transactionId)
 	} else {
-		builder.WithMessageTemplate("Error occured during processing, details are logged")
+		builder.WithMessageTemplate(msgTemplateWithoutTxId)
 	}
 
-	// make sure we have a logger - we will need it
-	logger := loggerToUse
-	if logger == nil {
-		logger = getDefaultLogger()
+	if !isFault {
+		return
 	}
-	logEvent := logger.WithLabels(logLabels)
 
-	// is transactionId in labels?
-	if transactionId != "" && !slices.ContainsFunc(logLabels, func(item kt_logging.Label) bool { return item.GetStringValue() == transactionId }) {
-		// let's enforce we will really decorate the log event with the transaction id!
-		logEvent = logEvent.WithLabel(kt_logging.StringLabel("trId", transactionId))
+	// we can inherit the retry calssification for sure
+	builder.WithIsRetryable(fault.IsRetryable())
+	audienceMsgTemplates := fault.GetMessageTemplatesByAudience()
+	userMsgTemplate := audienceMsgTemplates[MSGAUDIENCE_USER]
+	if preserveMessage && conversion.kindWasKept && fault.GetMessageTemplate() != "" {
+		// the kind was whitelisted and we were asked to keep the original message - the user facing message stays an audience message
+		// (if the original has no message at all then we stay with the generic one)
+		userMsgTemplate = fault.GetMessageTemplate()
+		builder.WithMessageTemplate(userMsgTemplate)
+	} else if len(userMsgTemplate) > 0 {
+		// the error has a user facing message - let's use this as error message!
+		builder.WithMessageTemplate(userMsgTemplate)
+		// and remove this from the msg templates
+		delete(audienceMsgTemplates, MSGAUDIENCE_USER)
+		// and we don't need the transactionId label either
+		//builder.WithoutLabels("transactionId")
 	}
-	if isFault {
-		logEvent.
-			Warn(
-				"Unsafe error captured which we turn into a public Fault (kindKept: %t, inheritErrorCodes: %t) - hiding unsafe details. Orig error was: %s",
-				kindWasKept, inheritErrorCodes, kt_utils.VarPrinter{TheVar: fault},
-			)
-		// we can inherit the retry calssification for sure
-		builder.WithIsRetryable(fault.IsRetryable())
-		audienceMsgTemplates := fault.GetMessageTemplatesByAudience()
-		userMsgTemplate := audienceMsgTemplates[MSGAUDIENCE_USER]
-		if len(userMsgTemplate) > 0 {
-			// the error has a user facing message - let's use this as error message!
-			builder.WithMessageTemplate(userMsgTemplate)
-			// and remove this from the msg templates
-			delete(audienceMsgTemplates, MSGAUDIENCE_USER)
-			// and we don't need the transactionId label either
-			//builder.WithoutLabels("transactionId")
+	// inherit the remaining audience messages into the new error
+	builder.WithMessageTemplatesByAudience(audienceMsgTemplates)
+	// we keep those labels which are required to resolve any of these messages - but only those
+	neededVariables := kt_utils.StringExtractVariableNames(userMsgTemplate)
+	for _, audienceMsgTemplate := range audienceMsgTemplates {
+		neededVariables.Union(kt_utils.StringExtractVariableNames(audienceMsgTemplate))
+	}
+	publicLabelKeys := fault.GetPublicLabelKeys()
+	for key, value := range fault.GetLabels() {
+		if !neededVariables.Contains(key) {
+			continue
 		}
-		// inherit the remaining audience messages into the new error
-		builder.WithMessageTemplatesByAudience(audienceMsgTemplates)
-		// we keep those labels which are required to resolve any of these messages - but only those
-		neededVariables := kt_utils.StringExtractVariableNames(userMsgTemplate)
-		for _, audienceMsgTemplate := range audienceMsgTemplates {
-			neededVariables.Union(kt_utils.StringExtractVariableNames(audienceMsgTemplate))
+		if len(publicLabelKeys) == 0 {
+			// no markers at all - we keep the label but note this
+			builder.WithLabel(key, value)
+			conversion.unmarkedLabelKeys = append(conversion.unmarkedLabelKeys, key)
+		} else if slices.Contains(publicLabelKeys, key) {
+			builder.WithLabel(key, value)
+		} else {
+			conversion.droppedLabelKeys = append(conversion.droppedLabelKeys, key)
 		}
-		for key, value := range fault.GetLabels() {
-			if neededVariables.Contains(key) {
-				builder.WithLabel(key, value)
-			}
+	}
+	slices.Sort(conversion.unmarkedLabelKeys)
+	slices.Sort(conversion.droppedLabelKeys)
+	return
+}
+
+// Turns a bunch of errors into one single public Fault instance. Useful if e.g. you fan out parallel work and collect the errors but want to respond with
+// one consolidated public error.
+//
+// What happens is that
+//   - Nil errors are filtered out - if nothing remains then Nil is returned.
+//   - Each remaining error is converted one by one with `NewPublicFaultFromAnyError()` (so logging etc happens - see the description there!). If there is
+//     only one error then its converted form is returned simply.
+//   - Otherwise the first converted Fault is taken as the base of the aggregate Fault, and the resolved messages of the rest of the converted Faults are
+//     added as label "additionalErrors" (a list of strings). The aggregate Fault keeps the reference and the instance id (see `fault.GetInstanceId()`)
+//     of the first converted Fault - so the ones the client sees can be found in the logs.
+//   - The aggregate Fault is retryable only if every converted Fault is retryable.
+//   - The causes of the aggregate Fault are the original errors (see `fault.GetCauses()`).
+//
+// Arguments are the same as for `NewPublicFaultFromAnyError()` - see there!
+func NewPublicFaultFromErrors(errs []error, transactionId string, loggerToUse *kt_logging.Logger, options ...ConversionOption) Fault {
+	originals := make([]error, 0, len(errs))
+	converted := make([]Fault, 0, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
 		}
-	} else {
-		logEvent.
-			Warn("Unsafe error captured which we turn into a public Fault - hiding unsafe details. Orig error was: %s",
-				kt_utils.VarPrinter{TheVar: original},
-			)
+		originals = append(originals, err)
+		converted = append(converted, NewPublicFaultFromAnyError(err, transactionId, loggerToUse, options...))
+	}
+	if len(converted) == 0 {
+		return nil
+	}
+	if len(converted) == 1 {
+		return converted[0]
 	}
 
-	return builder.Build()
+	first := converted[0]
+	allRetryable := true
+	additionalErrors := make([]string, 0, len(converted)-1)
+	for i, fault := range converted {
+		allRetryable = allRetryable && fault.IsRetryable()
+		if i > 0 {
+			additionalErrors = append(additionalErrors, fault.GetMessage())
+		}
+	}
+
+	builder := NewPublicFaultBuilder(first.GetKind())
+	// the merged Fault is not a new error - just another form of the original ones
+	builder.uncounted = true
+	// the instance id goes together with the "instanceId" label (and the log) of the first converted Fault
+	builder.fault.InstanceId = first.GetInstanceId()
+	return builder.
+		WithMessageTemplate(first.GetMessageTemplate()).
+		WithMessageTemplatesByAudience(first.GetMessageTemplatesByAudience()).
+		WithErrorCodes(first.GetErrorCodes()...).
+		WithLabels(first.GetLabels()).
+		WithLabel("additionalErrors", additionalErrors).
+		WithReference(first.GetReference()).
+		WithIsRetryable(allRetryable).
+		WithCauses(originals...).
+		Build()
 }
 
 // Returns the gRPC status code you should use in the error response for the given `Fault`.
@@ -224,7 +517,7 @@ func NewPublicFaultFromAnyError(original error, transactionId string, loggerToUs
 //
 // Note: there is an alias for this method as `fault.GetGrpcStatusCode()` - if you prefer that style more.
 func GetGrpcStatusCodeForFault(fault Fault) (grpcStatus codes.Code) {
-	if fault == nil {
+	if IsNoFault(fault) {
 		grpcStatus = codes.OK
 		return
 	}
@@ -238,8 +531,16 @@ func GetGrpcStatusCodeForFault(fault Fault) (grpcStatus codes.Code) {
 	switch fault.GetKind() {
 	case AuthenticationFault:
 		grpcStatus = codes.Unauthenticated
+		if fault.HasErrorCode(AUTHENTICATION_ERRCODE_NOT_SUPPORTED) {
+			// the auth method is not supported by us
+			grpcStatus = codes.Unimplemented
+		}
 	case AuthorizationFault:
 		grpcStatus = codes.PermissionDenied
+		if fault.HasErrorCode(AUTHORIZATION_ERRCODE_FAILED) && !fault.HasErrorCode(AUTHORIZATION_NO_PERMISSION) {
+			// the authorization process failed - this is not a denial
+			grpcStatus = codes.Internal
+		}
 	case ResourceNotFoundFault:
 		grpcStatus = codes.NotFound
 	case ConstraintViolationFault:
@@ -247,6 +548,9 @@ func GetGrpcStatusCodeForFault(fault Fault) (grpcStatus codes.Code) {
 		if fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_ID_ALREADY_TAKEN) ||
 			fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST) {
 			grpcStatus = codes.AlreadyExists
+		} else if fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT) {
+			// the conventional optimistic concurrency code
+			grpcStatus = codes.Aborted
 		} else if fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_DOES_NOT_EXIST) {
 			grpcStatus = codes.NotFound
 		}
@@ -255,7 +559,10 @@ func GetGrpcStatusCodeForFault(fault Fault) (grpcStatus codes.Code) {
 	case NotImplementedFault:
 		grpcStatus = codes.Unimplemented
 	case IllegalStateFault:
-		if fault.HasErrorCode(ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE) ||
+		// the same precedence as in `GetHttpStatusCodeForFault()` - rate limiting wins
+		if fault.HasErrorCode(ILLEGALSTATE_ERRCODE_RATE_LIMITED) {
+			grpcStatus = codes.ResourceExhausted
+		} else if fault.HasErrorCode(ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE) ||
 			fault.HasErrorCode(ILLEGALSTATE_ERRCODE_TIMED_OUT) {
 			grpcStatus = codes.Unavailable
 		} else if fault.HasErrorCode(ILLEGALSTATE_ERRCODE_EXHAUSTED) {
@@ -268,13 +575,28 @@ func GetGrpcStatusCodeForFault(fault Fault) (grpcStatus codes.Code) {
 	return
 }
 
+// Returns the category of the given kind - `KINDCATEGORY_CLIENT` or `KINDCATEGORY_SERVER`.
+//
+// `ValidationFault`, `AuthenticationFault`, `AuthorizationFault`, `ResourceNotFoundFault` and `ConstraintViolationFault` are caused by the client (4xx
+// style) - all other kinds (including custom ones) are server side problems (5xx style).
+// Please note: this is purely based on the kind - while e.g. `GetHttpStatusCodeForFault()` also considers if the Fault is public or not.
+func KindCategory(kind FaultKind) string {
+	switch kind {
+	case ValidationFault, AuthenticationFault, AuthorizationFault, ResourceNotFoundFault, ConstraintViolationFault:
+		return KINDCATEGORY_CLIENT
+	default:
+		return KINDCATEGORY_SERVER
+	}
+}
+
 // Returns the HTTP status code you should use in the error response for the given `Fault`.
 //
 // IMPORTANT! In case the `Fault` is not public then it is always 500 INTERNAL ERROR - otherwise it is determined from the attributes and the kind of the Fault.
+// Non-fatal Faults (see `fault.IsNonFatal()`) are the exception - they always get 200 OK as they travel alongside a successful result.
 //
 // Note: there is an alias for this method as `fault.GetHttpStatusCode()` - if you prefer that style more.
 func GetHttpStatusCodeForFault(fault Fault) (httpStatus int) {
-	if fault == nil {
+	if IsNoFault(fault) || fault.IsNonFatal() {
 		httpStatus = 200
 		return
 	}
@@ -299,7 +621,8 @@ func GetHttpStatusCodeForFault(fault Fault) (httpStatus int) {
 		// PRECONDITION_FAILED
 		httpStatus = 412
 		if fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_ID_ALREADY_TAKEN) ||
-			fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST) {
+			fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST) ||
+			fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT) {
 			// CONFLICT
 			httpStatus = 409
 		} else if fault.HasErrorCode(CONSTRAINTVIOLATION_ERRCODE_DOES_NOT_EXIST) {
@@ -313,7 +636,10 @@ func GetHttpStatusCodeForFault(fault Fault) (httpStatus int) {
 		// NOT IMPLEMENTED
 		httpStatus = 501
 	case IllegalStateFault:
-		if fault.HasErrorCode(ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE) || fault.HasErrorCode(ILLEGALSTATE_ERRCODE_EXHAUSTED) ||
+		if fault.HasErrorCode(ILLEGALSTATE_ERRCODE_RATE_LIMITED) {
+			// TOO_MANY_REQUESTS
+			httpStatus = 429
+		} else if fault.HasErrorCode(ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE) || fault.HasErrorCode(ILLEGALSTATE_ERRCODE_EXHAUSTED) ||
 			fault.HasErrorCode(ILLEGALSTATE_ERRCODE_TIMED_OUT) {
 			// SERVICE_UNAVAILABLE
 			httpStatus = 503
@@ -326,6 +652,91 @@ func GetHttpStatusCodeForFault(fault Fault) (httpStatus int) {
 	return
 }
 
+// Just like `GetHttpStatusCodeForFault()` but this one is also walking the cause chain of the `Fault` and checks the public Faults there as well.
+// It returns the most client-meaningful HTTP status code: if any public Fault in the chain (starting with the Fault itself) maps to a 4xx status code then
+// the first such one is returned. Otherwise the status code of the Fault itself is returned.
+//
+// IMPORTANT! In case the `Fault` is not public then it is always 500 INTERNAL ERROR - no matter what is in the cause chain.
+//
+// Note: there is an alias for this method as `fault.GetHttpStatusCodeFromChain()` - if you prefer that style more.
+func GetHttpStatusCodeFromChainForFault(fault Fault) (httpStatus int) {
+	httpStatus = GetHttpStatusCodeForFault(fault)
+	if fault == nil || !fault.IsPublic() || fault.IsNonFatal() || isClientHttpStatus(httpStatus) {
+		return
+	}
+
+	walkErrorTree(fault, func(err error, depth int) bool {
+		if depth == 1 {
+			// this is the Fault itself
+			return true
+		}
+		isFault, causeFault := IsFault(err)
+		if isFault && causeFault.IsPublic() {
+			causeStatus := GetHttpStatusCodeForFault(causeFault)
+			if isClientHttpStatus(causeStatus) {
+				httpStatus = causeStatus
+				return false
+			}
+		}
+		return true
+	})
+	return
+}
+
+func isClientHttpStatus(httpStatus int) bool {
+	return httpStatus >= 400 && httpStatus < 500
+}
+
+// Walks the chain of errors starting with the given error (so the first invocation gets the error itself) - invoking the callback on each of them.
+// If the callback returns false the walk stops. This works with any error - the chain can freely mix `Fault`s and other errors.
+// The chain might branch: the next elements of a `Fault` are all its causes (see `GetCauses()`), of other errors `Unwrap() []error` (e.g. `errors.Join()`)
+// or `errors.Unwrap()`. The branches are walked depth first, in order. Cycles are detected and an error is never visited twice.
+// See also the member function `fault.WalkCauses()`.
+func WalkErrorChain(err error, fn func(err error) bool) {
+	walkErrorTree(err, func(err error, _ int) bool { return fn(err) })
+}
+
+// The engine of `WalkErrorChain()` - the callback also gets the depth of the error (the starting error is on depth 1).
+func walkErrorTree(err error, fn func(err error, depth int) bool) {
+	visited := make(map[error]bool)
+	var walk func(err error, depth int) bool
+	walk = func(err error, depth int) bool {
+		if err == nil {
+			return true
+		}
+		if reflect.TypeOf(err).Comparable() {
+			if visited[err] {
+				return true
+			}
+			visited[err] = true
+		}
+		if !fn(err, depth) {
+			return false
+		}
+		for _, next := range nextErrorsInChain(err) {
+			if !walk(next, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(err, 1)
+}
+
+// Returns the errors following the given one in the chain - see `WalkErrorChain()`.
+func nextErrorsInChain(err error) []error {
+	if isFault, fault := IsFault(err); isFault {
+		return fault.GetCauses()
+	}
+	if multiErr, isMulti := err.(interface{ Unwrap() []error }); isMulti {
+		return multiErr.Unwrap()
+	}
+	if next := errors.Unwrap(err); next != nil {
+		return []error{next}
+	}
+	return nil
+}
+
 // Alias over the Fault's member function `fault.ToNaturalJSON()` - see description there!
 // You can also use the member function if you prefer that style more in your code.
 func GetFaultAsNaturalJSON(fault Fault, forAudience string, options ...SerializationOption) ([]byte, error) {
@@ -338,6 +749,94 @@ func GetFaultAsFullJSON(fault Fault, options ...SerializationOption) ([]byte, er
 	return fault.ToFullJSON(options...)
 }
 
+// Returns a human-readable description of every difference between the two Faults - one line per differing field (kind, public and retryable flags,
+// severity, source, message templates, error codes and labels) - or empty string if there is no difference. Meant for test failure messages, e.g.
+// comparing against golden Faults. The instance id and the reference are not compared as they are (typically) generated.
+//
+// Nil operands (and the `NoFault` sentinel) are handled: two of them are equal, otherwise the missing side is reported.
+func DiffFaults(a Fault, b Fault) string {
+	aMissing, bMissing := IsNoFault(a), IsNoFault(b)
+	if aMissing || bMissing {
+		if aMissing == bMissing {
+			return ""
+		}
+		if aMissing {
+			return fmt.Sprintf("a: no Fault, b: %s", b.String())
+		}
+		return fmt.Sprintf("a: %s, b: no Fault", a.String())
+	}
+
+	diffs := make([]string, 0)
+	addDiff := func(field string, aValue any, bValue any) {
+		if !reflect.DeepEqual(aValue, bValue) {
+			diffs = append(diffs, fmt.Sprintf("%s: %#v != %#v", field, aValue, bValue))
+		}
+	}
+	addDiff("kind", a.GetKind(), b.GetKind())
+	addDiff("public", a.IsPublic(), b.IsPublic())
+	addDiff("retryable", a.IsRetryable(), b.IsRetryable())
+	addDiff("severity", a.GetSeverity().String(), b.GetSeverity().String())
+	addDiff("source", a.GetSource(), b.GetSource())
+	addDiff("message template", a.GetMessageTemplate(), b.GetMessageTemplate())
+	for _, audience := range sortedUnion(a.GetMessageTemplatesByAudience(), b.GetMessageTemplatesByAudience()) {
+		addDiff(fmt.Sprintf("message template for audience '%s'", audience), a.GetMessageTemplateForAudience(audience), b.GetMessageTemplateForAudience(audience))
+	}
+
+	aCodes, bCodes := a.GetErrorCodes(), b.GetErrorCodes()
+	allCodes := append(slices.Clone(aCodes), bCodes...)
+	slices.Sort(allCodes)
+	for _, code := range slices.Compact(allCodes) {
+		if !slices.Contains(bCodes, code) {
+			diffs = append(diffs, fmt.Sprintf("error code '%s': only in a", code))
+		} else if !slices.Contains(aCodes, code) {
+			diffs = append(diffs, fmt.Sprintf("error code '%s': only in b", code))
+		}
+	}
+
+	aLabels, bLabels := a.GetLabels(), b.GetLabels()
+	for _, key := range sortedUnion(aLabels, bLabels) {
+		aValue, inA := aLabels[key]
+		bValue, inB := bLabels[key]
+		switch {
+		case !inB:
+			diffs = append(diffs, fmt.Sprintf("label '%s': only in a (%#v)", key, aValue))
+		case !inA:
+			diffs = append(diffs, fmt.Sprintf("label '%s': only in b (%#v)", key, bValue))
+		default:
+			addDiff(fmt.Sprintf("label '%s'", key), aValue, bValue)
+		}
+	}
+	return strings.Join(diffs, "\n")
+}
+
+// Returns only the whitelisted labels of the Fault - with their values stringified (`fmt.Sprint()`). Meant for building metric labels (e.g. Prometheus)
+// from Faults: using all the labels (or even their values freely) easily leads to a cardinality explosion of the series. Labels the Fault does not have
+// are simply missing from the result. Returns an empty map if there is no Fault.
+func BoundedLabels(fault Fault, allowed ...string) map[string]string {
+	bounded := make(map[string]string, len(allowed))
+	if IsNoFault(fault) {
+		return bounded
+	}
+	for _, key := range allowed {
+		if value, found := fault.GetLabel(key); found {
+			bounded[key] = fmt.Sprint(value)
+		}
+	}
+	return bounded
+}
+
+// Returns the keys of both maps - sorted, without duplicates.
+func sortedUnion[V any](a map[string]V, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, found := a[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
 func getDefaultLogger() *kt_logging.Logger {
 	return kt_logging.GetLogger("keytiles.errorhandling")
 }
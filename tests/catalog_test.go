@@ -0,0 +1,105 @@
+package kt_error_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultCatalog(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Loading a YAML catalog and creating Faults from it
+
+	// ---- GIVEN
+	yamlCatalog := `
+quota_exceeded:
+  kind: illegal_state
+  public: true
+  message: "quota of tenant {tenantId} is exceeded"
+  messagesByAudience:
+    user: "you have used up your quota"
+  errorCodes: [quota_exceeded]
+  isRetryable: false
+user_not_found:
+  kind: resource_not_found
+  message: "user {userId} not found"
+`
+	// ---- WHEN
+	catalog, err := kt_errors.LoadFaultCatalog(strings.NewReader(yamlCatalog))
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(catalog))
+
+	// ---- WHEN
+	fault := kt_errors.NewFaultFromCatalog(catalog, "quota_exceeded", map[string]any{"tenantId": "t-1"})
+	// ---- THEN
+	assert.Equal(t, kt_errors.IllegalStateFault, fault.GetKind())
+	assert.True(t, fault.IsPublic())
+	assert.False(t, fault.IsRetryable())
+	assert.Equal(t, []string{"quota_exceeded"}, fault.GetErrorCodes())
+	assert.Equal(t, "quota of tenant t-1 is exceeded", fault.GetMessage())
+	assert.Equal(t, "you have used up your quota", fault.GetMessageForAudience("user"))
+
+	// ---- WHEN
+	fault = kt_errors.NewFaultFromCatalog(catalog, "user_not_found", map[string]any{"userId": "u-1"})
+	// ---- THEN
+	assert.Equal(t, kt_errors.ResourceNotFoundFault, fault.GetKind())
+	assert.False(t, fault.IsPublic())
+	assert.Equal(t, "user u-1 not found", fault.GetMessage())
+
+	// ---- WHEN
+	// unknown id
+	fault = kt_errors.NewFaultFromCatalog(catalog, "no_such_id", nil)
+	// ---- THEN
+	assert.Equal(t, kt_errors.IllegalStateFault, fault.GetKind())
+	assert.True(t, fault.HasErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_CODE_BUG))
+	assert.Equal(t, "Unknown fault catalog id 'no_such_id'", fault.GetMessage())
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// JSON works too
+
+	// ---- WHEN
+	catalog, err = kt_errors.LoadFaultCatalog(strings.NewReader(`{"bad_input": {"kind": "validation", "public": true, "message": "bad input"}}`))
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, kt_errors.ValidationFault, catalog["bad_input"].Kind)
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// Broken documents
+
+	// ---- WHEN
+	catalog, err = kt_errors.LoadFaultCatalog(strings.NewReader("entry: [this is not a template"))
+	// ---- THEN
+	assert.Nil(t, catalog)
+	isFault, fault := kt_errors.IsFault(err)
+	assert.True(t, isFault)
+	assert.Equal(t, kt_errors.ValidationFault, fault.GetKind())
+	assert.True(t, fault.HasErrorCode(kt_errors.VALIDATION_ERRCODE_WRONG_FORMAT))
+	assert.NotNil(t, fault.GetCause())
+
+	// ---- WHEN
+	// unknown kind
+	catalog, err = kt_errors.LoadFaultCatalog(strings.NewReader("entry:\n  kind: no_such_kind\n"))
+	// ---- THEN
+	assert.Nil(t, catalog)
+	isFault, fault = kt_errors.IsFault(err)
+	assert.True(t, isFault)
+	assert.True(t, fault.HasErrorCode(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE))
+	assert.Equal(t, "Fault catalog entry 'entry' has unknown kind 'no_such_kind' - see `RegisterFaultKinds()`", fault.GetMessage())
+
+	// ---- WHEN
+	// empty document is an empty catalog
+	catalog, err = kt_errors.LoadFaultCatalog(strings.NewReader(""))
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(catalog))
+}
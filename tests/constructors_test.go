@@ -0,0 +1,183 @@
+package kt_error_test
+
+import (
+	"testing"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiFieldValidationFault(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Nothing failed
+
+	// ---- WHEN
+	fault := kt_errors.NewMultiFieldValidationFault(map[string]string{}, true)
+	// ---- THEN
+	assert.Nil(t, fault)
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Three fields failed
+
+	// ---- GIVEN
+	fieldErrors := map[string]string{
+		"name":  kt_errors.VALIDATION_ERRCODE_MISSING_MANDATORY,
+		"email": kt_errors.VALIDATION_ERRCODE_WRONG_FORMAT,
+		"age":   "",
+	}
+
+	// ---- WHEN
+	fault = kt_errors.NewMultiFieldValidationFault(fieldErrors, true)
+
+	// ---- THEN
+	assert.Equal(t, kt_errors.ValidationFault, fault.GetKind())
+	assert.True(t, fault.IsPublic())
+	assert.Equal(t, 400, fault.GetHttpStatusCode())
+	assert.Equal(t, "Validation failed on 3 field(s)", fault.GetMessage())
+	assert.ElementsMatch(
+		t,
+		[]string{kt_errors.VALIDATION_ERRCODE_MISSING_MANDATORY, kt_errors.VALIDATION_ERRCODE_WRONG_FORMAT, kt_errors.VALIDATION_ERRCODE_INVALID_VALUE},
+		fault.GetErrorCodes(),
+	)
+	fields, found := fault.GetLabel("fields")
+	assert.True(t, found)
+	assert.Equal(
+		t,
+		map[string]string{
+			"name":  kt_errors.VALIDATION_ERRCODE_MISSING_MANDATORY,
+			"email": kt_errors.VALIDATION_ERRCODE_WRONG_FORMAT,
+			"age":   kt_errors.VALIDATION_ERRCODE_INVALID_VALUE,
+		},
+		fields,
+	)
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// Non-public variant
+
+	// ---- WHEN
+	fault = kt_errors.NewMultiFieldValidationFault(fieldErrors, false)
+	// ---- THEN
+	assert.False(t, fault.IsPublic())
+	assert.Equal(t, 500, fault.GetHttpStatusCode())
+}
+
+func TestFieldValidationFaults(t *testing.T) {
+
+	// ---- WHEN
+	missing := kt_errors.NewMissingFieldFault("name")
+	invalid := kt_errors.NewInvalidValueFault("age", -3)
+	wrongType := kt_errors.NewWrongTypeFault("email", "string", "number")
+
+	// ---- THEN
+	for _, fault := range []kt_errors.Fault{missing, invalid, wrongType} {
+		assert.Equal(t, kt_errors.ValidationFault, fault.GetKind())
+		assert.True(t, fault.IsPublic())
+		assert.Equal(t, 400, fault.GetHttpStatusCode())
+	}
+
+	assert.Equal(t, []string{kt_errors.VALIDATION_ERRCODE_MISSING_MANDATORY}, missing.GetErrorCodes())
+	assert.Equal(t, map[string]any{"field": "name"}, missing.GetLabels())
+	assert.Equal(t, "Mandatory field 'name' is missing", missing.GetMessage())
+
+	assert.Equal(t, []string{kt_errors.VALIDATION_ERRCODE_INVALID_VALUE}, invalid.GetErrorCodes())
+	assert.Equal(t, map[string]any{"field": "age", "value": -3}, invalid.GetLabels())
+	assert.Equal(t, "Field 'age' has invalid value '-3'", invalid.GetMessage())
+
+	assert.Equal(t, []string{kt_errors.VALIDATION_ERRCODE_WRONG_DATATYPE}, wrongType.GetErrorCodes())
+	assert.Equal(t, map[string]any{"field": "email", "expected": "string", "got": "number"}, wrongType.GetLabels())
+	assert.Equal(t, "Field 'email' has wrong type - expected string but got number", wrongType.GetMessage())
+}
+
+func TestCommonKindFaults(t *testing.T) {
+
+	// ---- WHEN
+	notFound := kt_errors.NewNotFoundFault("user", "u-1")
+	alreadyExists := kt_errors.NewAlreadyExistsFault("user", "u-1")
+	versionConflict := kt_errors.NewVersionConflictFault(3, 4)
+	noPermission := kt_errors.NewNoPermissionFault("delete users")
+
+	// ---- THEN
+	assert.Equal(t, kt_errors.ResourceNotFoundFault, notFound.GetKind())
+	assert.Equal(t, 404, notFound.GetHttpStatusCode())
+	assert.Equal(t, "user 'u-1' does not exist", notFound.GetMessage())
+	assert.Equal(t, map[string]any{"resourceType": "user", "id": "u-1"}, notFound.GetLabels())
+
+	assert.Equal(t, kt_errors.ConstraintViolationFault, alreadyExists.GetKind())
+	assert.Equal(t, 409, alreadyExists.GetHttpStatusCode())
+	assert.Equal(t, []string{kt_errors.CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST}, alreadyExists.GetErrorCodes())
+	assert.Equal(t, "user 'u-1' already exists", alreadyExists.GetMessage())
+
+	assert.Equal(t, kt_errors.ConstraintViolationFault, versionConflict.GetKind())
+	assert.Equal(t, 409, versionConflict.GetHttpStatusCode())
+	assert.Equal(t, []string{kt_errors.CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT}, versionConflict.GetErrorCodes())
+	assert.Equal(t, map[string]any{"assumedVersion": 3, "actualVersion": 4}, versionConflict.GetLabels())
+
+	assert.Equal(t, kt_errors.AuthorizationFault, noPermission.GetKind())
+	assert.Equal(t, 403, noPermission.GetHttpStatusCode())
+	assert.Equal(t, "No permission to delete users", noPermission.GetMessage())
+	assert.False(t, noPermission.IsRetryable())
+
+	for _, fault := range []kt_errors.Fault{notFound, alreadyExists, versionConflict, noPermission} {
+		assert.True(t, fault.IsPublic())
+	}
+
+	// ---- WHEN
+	nonPublicFaults := []kt_errors.Fault{
+		kt_errors.NewNotFoundFaultNonPublic("user", "u-1"),
+		kt_errors.NewAlreadyExistsFaultNonPublic("user", "u-1"),
+		kt_errors.NewVersionConflictFaultNonPublic(3, 4),
+		kt_errors.NewNoPermissionFaultNonPublic("delete users"),
+	}
+	// ---- THEN
+	for _, fault := range nonPublicFaults {
+		assert.False(t, fault.IsPublic())
+		assert.Equal(t, 500, fault.GetHttpStatusCode())
+	}
+}
+
+func TestNewFaultFromHttpStatus(t *testing.T) {
+
+	testCases := []struct {
+		status        int
+		expectedKind  kt_errors.FaultKind
+		expectedCodes []string
+		retryable     bool
+	}{
+		{400, kt_errors.ValidationFault, nil, false},
+		{401, kt_errors.AuthenticationFault, nil, false},
+		{403, kt_errors.AuthorizationFault, nil, false},
+		{404, kt_errors.ResourceNotFoundFault, nil, false},
+		{409, kt_errors.ConstraintViolationFault, []string{kt_errors.CONSTRAINTVIOLATION_ERRCODE_ALREADY_EXIST}, false},
+		{412, kt_errors.ConstraintViolationFault, []string{kt_errors.CONSTRAINTVIOLATION_ERRCODE_PRECONDITION_FAILED}, false},
+		{429, kt_errors.IllegalStateFault, []string{kt_errors.ILLEGALSTATE_ERRCODE_RATE_LIMITED}, true},
+		{500, kt_errors.RuntimeFault, nil, false},
+		{501, kt_errors.NotImplementedFault, nil, false},
+		{503, kt_errors.IllegalStateFault, []string{kt_errors.ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE}, true},
+	}
+
+	for _, testCase := range testCases {
+		// ---- WHEN
+		fault := kt_errors.NewFaultFromHttpStatus(testCase.status, "upstream said no")
+
+		// ---- THEN
+		assert.True(t, fault.IsPublic(), "status %d", testCase.status)
+		assert.Equal(t, testCase.expectedKind, fault.GetKind(), "status %d", testCase.status)
+		assert.ElementsMatch(t, testCase.expectedCodes, fault.GetErrorCodes(), "status %d", testCase.status)
+		assert.Equal(t, testCase.retryable, fault.IsRetryable(), "status %d", testCase.status)
+		assert.Equal(t, "upstream said no", fault.GetMessage(), "status %d", testCase.status)
+		status, _ := fault.GetLabel("http.status")
+		assert.Equal(t, testCase.status, status)
+	}
+
+	// and the mapping is reversible for the (non-ambiguous) status codes
+	for _, status := range []int{400, 401, 403, 404, 409, 412, 429, 501, 503} {
+		assert.Equal(t, status, kt_errors.NewFaultFromHttpStatus(status, "").GetHttpStatusCode())
+	}
+}
@@ -0,0 +1,214 @@
+package kt_error_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+
+	// ---- GIVEN
+	interceptor := kt_errors.UnaryServerInterceptor(nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(kt_errors.GRPC_TRANSACTIONID_METADATA_KEY, "tr-1"))
+	logs, detach := observeDefaultLogger()
+	defer detach()
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Non-public Fault - logged and turned into a generic Internal status
+
+	// ---- WHEN
+	resp, err := interceptor(ctx, "request", info, func(ctx context.Context, req any) (any, error) {
+		return nil, kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+			WithMessageTemplate("db {dbHost} is down").
+			WithLabel("dbHost", "secret-db.internal").
+			Build()
+	})
+	// ---- THEN
+	assert.Nil(t, resp)
+	grpcStatus, isStatus := status.FromError(err)
+	assert.True(t, isStatus)
+	assert.Equal(t, codes.Internal, grpcStatus.Code())
+	assert.NotContains(t, grpcStatus.Message(), "secret-db")
+	assert.NotContains(t, grpcStatus.Message(), "db ")
+	assert.Empty(t, grpcStatus.Details())
+	// the original was logged with the transaction id from the metadata
+	assert.Equal(t, 1, logs.FilterField(zap.String("trId", "tr-1")).Len())
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Public Fault - its status goes out
+
+	// ---- WHEN
+	_, err = interceptor(ctx, "request", info, func(ctx context.Context, req any) (any, error) {
+		return nil, kt_errors.NewNotFoundFault("user", "u-1")
+	})
+	// ---- THEN
+	grpcStatus, _ = status.FromError(err)
+	assert.Equal(t, codes.NotFound, grpcStatus.Code())
+	assert.Equal(t, "user 'u-1' does not exist", grpcStatus.Message())
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// Plain error
+
+	// ---- WHEN
+	_, err = interceptor(ctx, "request", info, func(ctx context.Context, req any) (any, error) {
+		return nil, errors.New("unsafe details")
+	})
+	// ---- THEN
+	grpcStatus, _ = status.FromError(err)
+	assert.Equal(t, codes.Internal, grpcStatus.Code())
+	assert.NotContains(t, grpcStatus.Message(), "unsafe details")
+
+	// ==================
+	// Scenario 4
+	// ==================
+	// gRPC status errors and context errors - handlers already returning proper statuses keep working
+
+	// ---- WHEN
+	_, err = interceptor(ctx, "request", info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.InvalidArgument, "name is missing")
+	})
+	// ---- THEN
+	grpcStatus, _ = status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, grpcStatus.Code())
+	assert.Equal(t, "name is missing", grpcStatus.Message())
+
+	// ---- WHEN
+	_, err = interceptor(ctx, "request", info, func(ctx context.Context, req any) (any, error) {
+		return nil, fmt.Errorf("loading user u-1: %w", context.DeadlineExceeded)
+	})
+	// ---- THEN
+	grpcStatus, _ = status.FromError(err)
+	assert.Equal(t, codes.DeadlineExceeded, grpcStatus.Code())
+	assert.Equal(t, "context deadline exceeded", grpcStatus.Message())
+
+	// ---- WHEN
+	_, err = interceptor(ctx, "request", info, func(ctx context.Context, req any) (any, error) {
+		return nil, context.Canceled
+	})
+	// ---- THEN
+	grpcStatus, _ = status.FromError(err)
+	assert.Equal(t, codes.Canceled, grpcStatus.Code())
+
+	// ==================
+	// Scenario 5
+	// ==================
+	// Success
+
+	// ---- WHEN
+	resp, err = interceptor(ctx, "request", info, func(ctx context.Context, req any) (any, error) {
+		return "response", nil
+	})
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+}
+
+func TestFaultToGrpcStatus(t *testing.T) {
+
+	// ---- WHEN
+	publicStatus := kt_errors.NewNotFoundFault("user", "u-1").ToGrpcStatus()
+	nonPublicStatus := kt_errors.NewNotFoundFaultNonPublic("user", "u-1").ToGrpcStatus()
+
+	// ---- THEN
+	assert.Equal(t, codes.NotFound, publicStatus.Code())
+	assert.Equal(t, "user 'u-1' does not exist", publicStatus.Message())
+	assert.Equal(t, codes.Internal, nonPublicStatus.Code())
+	assert.Equal(t, "", nonPublicStatus.Message())
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+
+	// ---- GIVEN
+	interceptor := kt_errors.UnaryClientInterceptor()
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// NotFound status is rehydrated into a ResourceNotFound Fault
+
+	// ---- WHEN
+	err := interceptor(context.Background(), "/test.Service/Method", "request", nil, nil,
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return status.Error(codes.NotFound, "user 'u-1' does not exist")
+		})
+	// ---- THEN
+	isFault, fault := kt_errors.IsFault(err)
+	assert.True(t, isFault)
+	assert.Equal(t, kt_errors.ResourceNotFoundFault, fault.GetKind())
+	assert.True(t, fault.IsPublic())
+	assert.False(t, fault.IsRetryable())
+	assert.Equal(t, "user 'u-1' does not exist", fault.GetMessage())
+	assert.Equal(t, "NotFound", fault.GetLabels()["grpc.code"])
+	// the status error is still there - existing status checks keep working
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	grpcStatus, isStatus := status.FromError(err)
+	assert.True(t, isStatus)
+	assert.Equal(t, codes.NotFound, grpcStatus.Code())
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Success and non-status errors are passed through
+
+	// ---- WHEN
+	err = interceptor(context.Background(), "/test.Service/Method", "request", nil, nil,
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return nil
+		})
+	// ---- THEN
+	assert.NoError(t, err)
+
+	// ---- GIVEN
+	plainErr := errors.New("plain error")
+	// ---- WHEN
+	err = interceptor(context.Background(), "/test.Service/Method", "request", nil, nil,
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return plainErr
+		})
+	// ---- THEN
+	assert.Same(t, plainErr, err)
+}
+
+func TestNewFaultFromGrpcStatus(t *testing.T) {
+
+	// ---- WHEN
+	unavailable := kt_errors.NewFaultFromGrpcStatus(status.New(codes.Unavailable, "backend is down"))
+	conflict := kt_errors.NewFaultFromGrpcStatus(status.New(codes.Aborted, "version conflict"))
+	unknown := kt_errors.NewFaultFromGrpcStatus(status.New(codes.Unknown, "something happened"))
+
+	// ---- THEN
+	assert.Equal(t, kt_errors.IllegalStateFault, unavailable.GetKind())
+	assert.True(t, unavailable.HasErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE))
+	assert.True(t, unavailable.IsRetryable())
+	assert.Equal(t, kt_errors.ConstraintViolationFault, conflict.GetKind())
+	assert.True(t, conflict.HasErrorCode(kt_errors.CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT))
+	assert.Equal(t, kt_errors.RuntimeFault, unknown.GetKind())
+	assert.True(t, unavailable.IsPublic())
+	// the internal details of the downstream are not public
+	assert.False(t, unknown.IsPublic())
+	assert.False(t, kt_errors.NewFaultFromGrpcStatus(status.New(codes.Internal, "db password is wrong")).IsPublic())
+	assert.False(t, kt_errors.NewFaultFromGrpcStatus(status.New(codes.DataLoss, "disk broken")).IsPublic())
+	// the remote text is not resolved as template
+	remote := kt_errors.NewFaultFromGrpcStatus(status.New(codes.InvalidArgument, "bad {grpc.code} and {grpcMessage}"))
+	assert.Equal(t, "bad {grpc.code} and {grpcMessage}", remote.GetMessage())
+	assert.Nil(t, kt_errors.NewFaultFromGrpcStatus(status.New(codes.OK, "")))
+	// round trip
+	assert.Equal(t, codes.NotFound, kt_errors.NewFaultFromGrpcStatus(status.New(codes.NotFound, "x")).ToGrpcStatus().Code())
+}
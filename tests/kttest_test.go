@@ -0,0 +1,209 @@
+package kt_error_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors/kttest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// A fake testing.TB which just records failures instead of stopping the test - so we can test the test helpers :-)
+type recordingT struct {
+	testing.TB
+	failed   bool
+	messages []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.failed = true
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestRequireNoFault(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// No error - no failure
+
+	// ---- GIVEN
+	recT := &recordingT{}
+	// ---- WHEN
+	kttest.RequireNoFault(recT, nil)
+	// ---- THEN
+	assert.False(t, recT.failed)
+	// ---- WHEN
+	// the NoFault sentinel is fine too
+	kttest.RequireNoFault(recT, kt_errors.NoFault)
+	// ---- THEN
+	assert.False(t, recT.failed)
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Fault with a cause - the full structured representation should appear
+
+	// ---- GIVEN
+	recT = &recordingT{}
+	cause := kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("inner problem").
+		WithErrorCodes(kt_errors.ILLEGALSTATE_ERRCODE_CONFIG_ERROR).
+		Build()
+	fault := kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).
+		WithMessageTemplate("outer problem with {var1}").
+		WithLabel("var1", "value1").
+		WithSource("mymodule", "myfunction").
+		WithCause(cause).
+		Build()
+	// ---- WHEN
+	kttest.RequireNoFault(recT, fault)
+	// ---- THEN
+	assert.True(t, recT.failed)
+	assert.Equal(t, 1, len(recT.messages))
+	assert.Contains(t, recT.messages[0], fault.String())
+	assert.Contains(t, recT.messages[0], fmt.Sprintf("cause: {%s}", cause.String()))
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// Plain error
+
+	// ---- GIVEN
+	recT = &recordingT{}
+	// ---- WHEN
+	kttest.RequireNoFault(recT, fmt.Errorf("plain error"))
+	// ---- THEN
+	assert.True(t, recT.failed)
+	assert.Contains(t, recT.messages[0], "plain error")
+}
+
+func TestAssertFault(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// All expectations met - no failure
+
+	// ---- GIVEN
+	recT := &recordingT{}
+	fault := kt_errors.NewNotFoundFault("user", "u-1")
+	retryable := false
+	public := true
+	// ---- WHEN
+	ok := kttest.AssertFault(recT, fault, kttest.Expectations{
+		Kind:       kt_errors.ResourceNotFoundFault,
+		Codes:      fault.GetErrorCodes(),
+		Retryable:  &retryable,
+		Public:     &public,
+		HttpStatus: 404,
+	})
+	// ---- THEN
+	assert.True(t, ok)
+	assert.False(t, recT.failed)
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Several mismatches - all of them reported
+
+	// ---- GIVEN
+	recT = &recordingT{}
+	public = false
+	// ---- WHEN
+	ok = kttest.AssertFault(recT, fault, kttest.Expectations{
+		Kind:       kt_errors.IllegalStateFault,
+		Codes:      []string{"no_such_code"},
+		Public:     &public,
+		HttpStatus: 500,
+	})
+	// ---- THEN
+	assert.False(t, ok)
+	assert.True(t, recT.failed)
+	assert.Equal(t, 4, len(recT.messages))
+	assert.Contains(t, recT.messages[0], "expected kind 'illegal_state' but got 'resource_not_found'")
+	assert.Contains(t, recT.messages[1], "'no_such_code'")
+	assert.Contains(t, recT.messages[2], "expected public=false")
+	assert.Contains(t, recT.messages[3], "expected HTTP status 500 but got 404")
+	assert.Contains(t, recT.messages[0], fault.String())
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// Not a Fault at all
+
+	// ---- GIVEN
+	recT = &recordingT{}
+	// ---- WHEN
+	ok = kttest.AssertFault(recT, fmt.Errorf("plain error"), kttest.Expectations{})
+	// ---- THEN
+	assert.False(t, ok)
+	assert.True(t, recT.failed)
+	assert.Contains(t, recT.messages[0], "plain error")
+}
+
+func TestExpectHttpStatusAndGrpcCode(t *testing.T) {
+
+	// ---- GIVEN
+	notFound := kt_errors.NewNotFoundFault("user", "u-1")
+	nonPublicNotFound := kt_errors.NewNotFoundFaultNonPublic("user", "u-1")
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Matching
+
+	// ---- WHEN
+	httpOk, httpReason := kttest.ExpectHttpStatus(notFound, 404)
+	grpcOk, grpcReason := kttest.ExpectGrpcCode(notFound, codes.NotFound)
+	// ---- THEN
+	assert.True(t, httpOk)
+	assert.Empty(t, httpReason)
+	assert.True(t, grpcOk)
+	assert.Empty(t, grpcReason)
+
+	// ---- WHEN
+	httpOk, _ = kttest.ExpectHttpStatus(nil, 200)
+	grpcOk, _ = kttest.ExpectGrpcCode(kt_errors.NoFault, codes.OK)
+	// ---- THEN
+	assert.True(t, httpOk)
+	assert.True(t, grpcOk)
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Mismatching
+
+	// ---- WHEN
+	httpOk, httpReason = kttest.ExpectHttpStatus(notFound, 400)
+	grpcOk, grpcReason = kttest.ExpectGrpcCode(notFound, codes.InvalidArgument)
+	// ---- THEN
+	assert.False(t, httpOk)
+	assert.Contains(t, httpReason, "expected HTTP status 400 but got 404 for kind 'resource_not_found'")
+	assert.False(t, grpcOk)
+	assert.Contains(t, grpcReason, "expected gRPC code InvalidArgument but got NotFound for kind 'resource_not_found'")
+
+	// ---- WHEN
+	// the non-public Fault maps to 500 - and the reason tells why
+	httpOk, httpReason = kttest.ExpectHttpStatus(nonPublicNotFound, 404)
+	grpcOk, grpcReason = kttest.ExpectGrpcCode(nonPublicNotFound, codes.NotFound)
+	// ---- THEN
+	assert.False(t, httpOk)
+	assert.Contains(t, httpReason, "expected HTTP status 404 but got 500 - the Fault is not public")
+	assert.False(t, grpcOk)
+	assert.Contains(t, grpcReason, "expected gRPC code NotFound but got Internal - the Fault is not public")
+
+	// ---- WHEN
+	httpOk, httpReason = kttest.ExpectHttpStatus(nil, 404)
+	// ---- THEN
+	assert.False(t, httpOk)
+	assert.Equal(t, "expected HTTP status 404 but got 200 - there is no Fault", httpReason)
+}
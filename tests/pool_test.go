@@ -0,0 +1,125 @@
+package kt_error_test
+
+import (
+	"testing"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPooledFaults(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Pooled Faults behave just like the normal ones
+
+	// ---- GIVEN
+	builder := kt_errors.NewPooledFaultBuilder(kt_errors.ValidationFault).
+		WithMessageTemplate("field {field} is invalid").
+		WithErrorCode(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+		WithLabel("field", "name")
+	// ---- WHEN
+	fault := builder.Build()
+	// ---- THEN
+	assert.Equal(t, kt_errors.ValidationFault, fault.GetKind())
+	assert.Equal(t, "field name is invalid", fault.GetMessage())
+	assert.True(t, fault.HasErrorCode(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE))
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Generation guard - a Fault released twice does not get into the pool twice
+
+	// ---- WHEN
+	released := kt_errors.Release(fault)
+	releasedAgain := kt_errors.Release(fault)
+	fault1 := builder.Build()
+	fault2 := builder.Build()
+	// ---- THEN
+	assert.True(t, released)
+	assert.False(t, releasedAgain)
+	// the Faults built after that are separate ones
+	assert.NotSame(t, fault1, fault2)
+	// and the recycled labels map does not leak between them
+	fault1.AddLabel("only", "in fault1")
+	_, found := fault2.GetLabel("only")
+	assert.False(t, found)
+	assert.Equal(t, "field name is invalid", fault2.GetMessage())
+
+	// ---- WHEN
+	// concurrent double release
+	fault = builder.Build()
+	results := make(chan bool, 2)
+	for range 2 {
+		go func() { results <- kt_errors.Release(fault) }()
+	}
+	// ---- THEN
+	// only one of them wins
+	assert.NotEqual(t, <-results, <-results)
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// A stale reference - kept after release while the struct went on to a new owner - can neither read nor release the new owner's Fault
+
+	// ---- GIVEN
+	stale := kt_errors.NewPooledFaultBuilder(kt_errors.RuntimeFault).WithMessageTemplate("runtime owner's fault").Build()
+	assert.True(t, kt_errors.Release(stale))
+	newOwners := make([]kt_errors.Fault, 0, 8)
+	for range 8 {
+		newOwners = append(newOwners, kt_errors.NewPooledFaultBuilder(kt_errors.AuthorizationFault).WithMessageTemplate("someone else").Build())
+	}
+
+	// ---- WHEN
+	releasedStale := kt_errors.Release(stale)
+	// ---- THEN
+	assert.False(t, releasedStale)
+	// the stale reference behaves like a nil Fault
+	assert.Equal(t, kt_errors.FaultKind(""), stale.GetKind())
+	assert.Equal(t, "", stale.GetMessage())
+	// the new owners are intact
+	for _, newOwner := range newOwners {
+		assert.Equal(t, kt_errors.AuthorizationFault, newOwner.GetKind())
+		assert.Equal(t, "someone else", newOwner.GetMessage())
+		assert.True(t, kt_errors.Release(newOwner))
+	}
+
+	// ==================
+	// Scenario 4
+	// ==================
+	// Not pooled Faults can not be released
+
+	// ---- THEN
+	assert.False(t, kt_errors.Release(kt_errors.NewFaultBuilder(kt_errors.ValidationFault).Build()))
+	// neither the copies of pooled Faults
+	pooledFault := builder.Build()
+	assert.False(t, kt_errors.Release(pooledFault.WithKindOverride(kt_errors.RuntimeFault)))
+	assert.False(t, kt_errors.Release(pooledFault.AsNonPublic()))
+	assert.True(t, kt_errors.Release(pooledFault))
+	assert.False(t, kt_errors.Release(kt_errors.NoFault))
+	assert.False(t, kt_errors.Release(nil))
+}
+
+func BenchmarkFaultBuild(b *testing.B) {
+	for b.Loop() {
+		fault := kt_errors.NewFaultBuilder(kt_errors.ValidationFault).
+			WithMessageTemplate("field {field} is invalid").
+			WithErrorCode(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+			WithLabel("field", "name").
+			Build()
+		_ = fault.GetMessage()
+	}
+}
+
+func BenchmarkPooledFaultBuild(b *testing.B) {
+	for b.Loop() {
+		fault := kt_errors.NewPooledFaultBuilder(kt_errors.ValidationFault).
+			WithMessageTemplate("field {field} is invalid").
+			WithErrorCode(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+			WithLabel("field", "name").
+			Build()
+		_ = fault.GetMessage()
+		kt_errors.Release(fault)
+	}
+}
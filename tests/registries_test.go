@@ -0,0 +1,372 @@
+package kt_error_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/keytiles/lib-logging-golang/v2/pkg/kt_logging"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Attaches an observer to the default logger of the library - so tests can check what was logged. The returned func detaches it.
+func observeDefaultLogger() (*observer.ObservedLogs, func()) {
+	return observeLogger(kt_logging.GetLogger("keytiles.errorhandling"))
+}
+
+// Attaches an observer to the given logger - so tests can check what was logged. The returned func detaches it.
+func observeLogger(logger *kt_logging.Logger) (*observer.ObservedLogs, func()) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	handlers := logger.GetHandlers()
+	handlers["test_observer"] = zap.New(core)
+	return logs, func() { delete(handlers, "test_observer") }
+}
+
+func TestFaultKindRegistry(t *testing.T) {
+
+	// ---- GIVEN
+	customKind := kt_errors.FaultKind("custom_registry_test")
+	assert.False(t, kt_errors.IsRegisteredFaultKind(customKind))
+	for _, kind := range allFaultKinds {
+		assert.True(t, kt_errors.IsRegisteredFaultKind(kind))
+	}
+
+	// ---- WHEN
+	kt_errors.RegisterFaultKinds(customKind, customKind, "")
+
+	// ---- THEN
+	assert.True(t, kt_errors.IsRegisteredFaultKind(customKind))
+	assert.False(t, kt_errors.IsRegisteredFaultKind(""))
+	assert.Contains(t, kt_errors.GetRegisteredFaultKinds(), customKind)
+}
+
+func TestDumpRegistries(t *testing.T) {
+
+	// ---- GIVEN
+	customKind := kt_errors.FaultKind("custom_dump_test")
+	kt_errors.RegisterFaultKinds(customKind)
+
+	// ---- WHEN
+	dump := kt_errors.DumpRegistries()
+
+	// ---- THEN
+	assert.Contains(t, dump["faultKinds"], customKind)
+	assert.Contains(t, dump["faultKinds"], kt_errors.ValidationFault)
+	kindHttpStatus := dump["kindHttpStatus"].(map[kt_errors.FaultKind]int)
+	assert.Equal(t, 400, kindHttpStatus[kt_errors.ValidationFault])
+	assert.Equal(t, 500, kindHttpStatus[customKind])
+	kindGrpcStatus := dump["kindGrpcStatus"].(map[kt_errors.FaultKind]string)
+	assert.Equal(t, "NotFound", kindGrpcStatus[kt_errors.ResourceNotFoundFault])
+	assert.Equal(t, "Internal", kindGrpcStatus[customKind])
+	kindRetryable := dump["kindRetryable"].(map[kt_errors.FaultKind]bool)
+	assert.False(t, kindRetryable[kt_errors.ValidationFault])
+	assert.True(t, kindRetryable[customKind])
+	// defaults of the other settings
+	assert.Equal(t, "fault", dump["defaultRenderTemplate"])
+	assert.Equal(t, kt_errors.NaturalJSONFieldNames{}, dump["naturalJSONFieldNames"])
+	assert.Equal(t, true, dump["faultStatsEnabled"])
+
+	// ---- WHEN
+	kt_errors.SetDefaultRenderTemplate(template.Must(template.New("custom").Parse(`{{.Kind}}`)))
+	defer kt_errors.SetDefaultRenderTemplate(nil)
+	kt_errors.SetNaturalJSONFieldNames(kt_errors.NaturalJSONFieldNames{Message: "detail"})
+	defer kt_errors.SetNaturalJSONFieldNames(kt_errors.NaturalJSONFieldNames{})
+	kt_errors.SetFaultStatsEnabled(false)
+	defer kt_errors.SetFaultStatsEnabled(true)
+	dump = kt_errors.DumpRegistries()
+
+	// ---- THEN
+	assert.Equal(t, "custom", dump["defaultRenderTemplate"])
+	assert.Equal(t, kt_errors.NaturalJSONFieldNames{Message: "detail"}, dump["naturalJSONFieldNames"])
+	assert.Equal(t, false, dump["faultStatsEnabled"])
+}
+
+func TestKindRetryabilityPolicy(t *testing.T) {
+
+	// ---- GIVEN
+	// defaults
+	assert.False(t, kt_errors.IsKindRetryabilityAllowed(kt_errors.ResourceNotFoundFault))
+	assert.False(t, kt_errors.IsKindRetryabilityAllowed(kt_errors.ValidationFault))
+	assert.True(t, kt_errors.IsKindRetryabilityAllowed(kt_errors.IllegalStateFault))
+	assert.True(t, kt_errors.IsKindRetryabilityAllowed(kt_errors.FaultKind("unknown_kind")))
+	assert.False(t, kt_errors.NewFaultBuilder(kt_errors.ResourceNotFoundFault).WithIsRetryable(true).Build().IsRetryable())
+
+	// ---- WHEN
+	// eventually consistent store - not found might be worth a retry
+	kt_errors.SetKindRetryabilityPolicy(kt_errors.ResourceNotFoundFault, true)
+	defer kt_errors.SetKindRetryabilityPolicy(kt_errors.ResourceNotFoundFault, false)
+	fault := kt_errors.NewFaultBuilder(kt_errors.ResourceNotFoundFault).WithIsRetryable(true).Build()
+
+	// ---- THEN
+	assert.True(t, kt_errors.IsKindRetryabilityAllowed(kt_errors.ResourceNotFoundFault))
+	assert.True(t, fault.IsRetryable())
+	// the flag sticks also through re-classification
+	assert.True(t, fault.WithKindOverride(kt_errors.ResourceNotFoundFault).IsRetryable())
+	// while other blocked kinds are still blocked
+	assert.False(t, fault.WithKindOverride(kt_errors.ValidationFault).IsRetryable())
+
+	// ---- WHEN
+	// blocking a by default allowed kind
+	kt_errors.SetKindRetryabilityPolicy(kt_errors.ConstraintViolationFault, false)
+	defer kt_errors.SetKindRetryabilityPolicy(kt_errors.ConstraintViolationFault, true)
+	fault = kt_errors.NewFaultBuilder(kt_errors.ConstraintViolationFault).WithIsRetryable(true).Build()
+
+	// ---- THEN
+	assert.False(t, fault.IsRetryable())
+}
+
+func TestStrictAudiences(t *testing.T) {
+
+	// ---- GIVEN
+	customAudience := "custom_audience_test"
+	assert.False(t, kt_errors.IsStrictAudiences())
+	assert.True(t, kt_errors.IsRegisteredAudience(kt_errors.MSGAUDIENCE_USER))
+	assert.False(t, kt_errors.IsRegisteredAudience(customAudience))
+	logs, detach := observeDefaultLogger()
+	defer detach()
+
+	// ---- WHEN
+	// not strict mode - anything goes silently
+	fault, err := kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).WithMessageTemplateForAudience(customAudience, "msg").BuildStrict()
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "msg", fault.GetMessageForAudience(customAudience))
+	assert.Equal(t, 0, logs.Len())
+
+	// ---- GIVEN
+	kt_errors.SetStrictAudiences(true)
+	defer kt_errors.SetStrictAudiences(false)
+
+	// ---- WHEN
+	// lenient path - normal Build() works but warns
+	fault = kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).
+		WithMessageTemplateForAudience(customAudience, "msg").
+		WithMessageTemplateForAudience(kt_errors.MSGAUDIENCE_USER, "user msg").
+		Build()
+	// ---- THEN
+	assert.Equal(t, "msg", fault.GetMessageForAudience(customAudience))
+	warnings := logs.FilterMessageSnippet(customAudience).TakeAll()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, zapcore.WarnLevel, warnings[0].Level)
+	assert.Equal(t, 0, logs.FilterMessageSnippet("'"+kt_errors.MSGAUDIENCE_USER+"'").Len())
+
+	// ---- WHEN
+	// strict path - rejected
+	fault, err = kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).
+		WithMessageTemplatesByAudience(map[string]string{customAudience: "msg", kt_errors.MSGAUDIENCE_USER: "user msg"}).
+		BuildStrict()
+	// ---- THEN
+	assert.Nil(t, fault)
+	assert.Error(t, err)
+	isFault, errFault := kt_errors.IsFault(err)
+	assert.True(t, isFault)
+	assert.Equal(t, kt_errors.IllegalStateFault, errFault.GetKind())
+	assert.True(t, errFault.HasErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_CODE_BUG))
+	assert.Equal(t, "Fault has message templates for unregistered audiences [custom_audience_test] - see `RegisterAudience()`", errFault.GetMessage())
+
+	// ---- WHEN
+	// after registration it is fine
+	logs.TakeAll()
+	kt_errors.RegisterAudience(customAudience)
+	fault, err = kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).WithMessageTemplateForAudience(customAudience, "msg").BuildStrict()
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "msg", fault.GetMessageForAudience(customAudience))
+	assert.Equal(t, 0, logs.FilterMessageSnippet(customAudience).Len())
+	assert.Contains(t, kt_errors.DumpRegistries()["audiences"], customAudience)
+}
+
+func TestPanicOnNilFault(t *testing.T) {
+
+	// ---- GIVEN
+	// a nil Fault pointer behind a non-nil interface - the bug we want to catch
+	nilFault := reflect.Zero(reflect.TypeOf(kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).Build())).Interface().(kt_errors.Fault)
+	assert.False(t, kt_errors.IsPanicOnNilFault())
+
+	// ---- WHEN / THEN
+	// lenient by default
+	assert.NotPanics(t, func() {
+		assert.Equal(t, "", nilFault.GetKind())
+		assert.Equal(t, "", nilFault.GetMessage())
+		assert.Empty(t, nilFault.GetErrorCodes())
+		nilFault.AddLabel("key", "value")
+	})
+
+	// ---- GIVEN
+	kt_errors.SetPanicOnNilFault(true)
+	defer kt_errors.SetPanicOnNilFault(false)
+
+	// ---- WHEN / THEN
+	assert.PanicsWithValue(t, "kt_errors: GetKind() invoked on a nil Fault - see `SetPanicOnNilFault()`", func() { nilFault.GetKind() })
+	assert.Panics(t, func() { nilFault.GetMessage() })
+	assert.Panics(t, func() { nilFault.AddLabel("key", "value") })
+	assert.Equal(t, true, kt_errors.DumpRegistries()["panicOnNilFault"])
+	// real Faults and the `NoFault` sentinel are not affected
+	assert.NotPanics(t, func() {
+		kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).Build().GetKind()
+		kt_errors.NoFault.GetKind()
+		kt_errors.NoFault.AddLabel("key", "value")
+	})
+}
+
+func TestLabelLimits(t *testing.T) {
+
+	// ---- GIVEN
+	// by default unlimited
+	longValue := strings.Repeat("x", 1000)
+	fault := kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).
+		WithLabels(map[string]any{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}).
+		WithLabel("long", longValue).
+		Build()
+	assert.Len(t, fault.GetLabels(), 6)
+	assert.Equal(t, longValue, fault.GetLabels()["long"])
+
+	kt_errors.SetMaxLabelCount(3)
+	defer kt_errors.SetMaxLabelCount(0)
+	kt_errors.SetMaxLabelValueLength(10)
+	defer kt_errors.SetMaxLabelValueLength(0)
+
+	// ---- WHEN
+	fault = kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).
+		WithLabel("long", longValue).
+		WithLabel("short", "short").
+		WithLabels(map[string]any{"count": 12345678901234, "dropped1": "value", "dropped2": "value"}).
+		// existing label can be still overwritten
+		WithLabel("short", "still short").
+		Build()
+	fault.AddLabel("dropped3", "value")
+
+	// ---- THEN
+	// string values are truncated - others are not
+	assert.Equal(
+		t,
+		map[string]any{"long": "xxxxxxxxxx...", "short": "still shor...", "count": 12345678901234},
+		fault.GetLabels(),
+	)
+
+	// ---- WHEN
+	fault = kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).
+		WithExactLabels(map[string]any{"d": 4, "c": 3, "b": 2, "a": 1}).
+		Build()
+	// ---- THEN
+	// capping is deterministic
+	assert.Equal(t, map[string]any{"a": 1, "b": 2, "c": 3}, fault.GetLabels())
+
+	labelLimits := kt_errors.DumpRegistries()["labelLimits"].(map[string]int)
+	assert.Equal(t, 3, labelLimits["maxLabelCount"])
+	assert.Equal(t, 10, labelLimits["maxLabelValueLength"])
+}
+
+func TestFaultStats(t *testing.T) {
+
+	// ---- GIVEN
+	kt_errors.ResetFaultStats()
+	defer kt_errors.ResetFaultStats()
+
+	// ---- WHEN
+	for range 3 {
+		kt_errors.NewFaultBuilder(kt_errors.ValidationFault).Build()
+	}
+	builder := kt_errors.NewPublicFaultBuilder(kt_errors.ResourceNotFoundFault)
+	builder.Build()
+	builder.Build()
+	// this does not build Faults
+	kt_errors.DumpRegistries()
+	// these are derived from an existing error - not counted
+	internalFault := kt_errors.NewFaultBuilder(kt_errors.ValidationFault).Build()
+	kt_errors.Sanitize(internalFault)
+	kt_errors.NewPublicFaultFromAnyError(internalFault, "", nil, kt_errors.OptionLogConfig(kt_logging.NoneLevel, true))
+
+	// ---- THEN
+	assert.Equal(
+		t,
+		map[kt_errors.FaultKind]int64{kt_errors.ValidationFault: 4, kt_errors.ResourceNotFoundFault: 2},
+		kt_errors.FaultStats(),
+	)
+
+	// ---- WHEN
+	// opting out
+	kt_errors.SetFaultStatsEnabled(false)
+	kt_errors.NewFaultBuilder(kt_errors.ValidationFault).Build()
+	kt_errors.SetFaultStatsEnabled(true)
+	// ---- THEN
+	assert.Equal(t, int64(4), kt_errors.FaultStats()[kt_errors.ValidationFault])
+
+	// ---- WHEN
+	kt_errors.ResetFaultStats()
+	// ---- THEN
+	assert.Empty(t, kt_errors.FaultStats())
+}
+
+func TestNaturalJSONFieldNames(t *testing.T) {
+
+	// ---- GIVEN
+	fault := kt_errors.NewPublicFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("quota of tenant {tenantId} is exceeded").
+		WithErrorCode("quota_exceeded").
+		WithIsRetryable(true).
+		WithLabel("tenantId", "t-1").
+		WithReference("ERR-TEST01").
+		Build()
+	defaultJson, err := fault.ToNaturalJSON("")
+	assert.NoError(t, err)
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Overridden keys are emitted - in the very same order, the not overridden keys stay as they were
+
+	// ---- GIVEN
+	kt_errors.SetNaturalJSONFieldNames(kt_errors.NaturalJSONFieldNames{
+		Retryable:  "retryable",
+		ErrorCodes: "error_code",
+		Reference:  "ref",
+	})
+	defer kt_errors.SetNaturalJSONFieldNames(kt_errors.NaturalJSONFieldNames{})
+
+	// ---- WHEN
+	jsonBytes, err := fault.ToNaturalJSON("")
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"kind":"illegal_state","message":"quota of tenant {tenantId} is exceeded","retryable":true,"error_code":["quota_exceeded"],"labels":{"tenantId":"t-1"},"ref":"ERR-TEST01"}`,
+		string(jsonBytes),
+	)
+
+	// ---- WHEN
+	var buffer strings.Builder
+	err = fault.WriteNaturalJSON(&buffer, "", kt_errors.PrettyPrint)
+	prettyJson, _ := fault.ToNaturalJSON("", kt_errors.PrettyPrint)
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, string(prettyJson)+"\n", buffer.String())
+	assert.Contains(t, buffer.String(), "\t\"error_code\": [")
+
+	// ---- WHEN
+	// PublicFault understands the overridden keys
+	var publicFault kt_errors.PublicFault
+	err = publicFault.UnmarshalJSON(jsonBytes)
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.True(t, publicFault.GetFault().IsRetryable())
+	assert.Equal(t, []string{"quota_exceeded"}, publicFault.GetFault().GetErrorCodes())
+	assert.Equal(t, "ERR-TEST01", publicFault.GetFault().GetReference())
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// The zero value restores the defaults
+
+	// ---- WHEN
+	kt_errors.SetNaturalJSONFieldNames(kt_errors.NaturalJSONFieldNames{})
+	jsonBytes, err = fault.ToNaturalJSON("")
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, string(defaultJson), string(jsonBytes))
+	assert.Equal(t, kt_errors.NaturalJSONFieldNames{}, kt_errors.GetNaturalJSONFieldNames())
+}
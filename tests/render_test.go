@@ -0,0 +1,99 @@
+package kt_error_test
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultRender(t *testing.T) {
+
+	// ---- GIVEN
+	customTemplate := template.Must(template.New("custom").Parse(
+		`{{.Kind}}|{{.Message}}|retryable={{.Retryable}}|source={{.Source}}|db={{index .Labels "dbHost"}}`,
+	))
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Public Fault with the default and a custom template
+
+	// ---- GIVEN
+	fault := kt_errors.NewPublicFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("db {dbHost} is down").
+		WithErrorCodes(kt_errors.ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE).
+		WithLabel("dbHost", "db-1").
+		WithSource("repo.Save").
+		Build()
+
+	// ---- WHEN
+	rendered, err := fault.Render(nil)
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "[illegal_state] unavailable_dependency: db db-1 is down", rendered)
+
+	// ---- WHEN
+	rendered, err = fault.Render(customTemplate)
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "illegal_state|db db-1 is down|retryable=false|source=repo.Save|db=db-1", rendered)
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Non-public Fault - the labels are redacted
+
+	// ---- GIVEN
+	fault = kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("db {dbHost} is down").
+		WithLabel("dbHost", "secret-db.internal").
+		WithSource("repo.Save").
+		Build()
+
+	// ---- WHEN
+	rendered, err = fault.Render(customTemplate)
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "illegal_state|db {dbHost} is down|retryable=false|source=repo.Save|db=[REDACTED]", rendered)
+
+	// ---- WHEN
+	rendered, err = fault.Render(nil)
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "[illegal_state] db {dbHost} is down", rendered)
+	assert.NotContains(t, rendered, "secret-db")
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// Template execution error is returned
+
+	// ---- WHEN
+	_, err = fault.Render(template.Must(template.New("broken").Parse(`{{.NoSuchField}}`)))
+	// ---- THEN
+	assert.Error(t, err)
+
+	// ==================
+	// Scenario 4
+	// ==================
+	// The default template can be changed - and restored
+
+	// ---- GIVEN
+	kt_errors.SetDefaultRenderTemplate(template.Must(template.New("custom").Parse(`{{.Kind}}: {{.Message}}`)))
+	defer kt_errors.SetDefaultRenderTemplate(nil)
+
+	// ---- WHEN
+	rendered, err = fault.Render(nil)
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "illegal_state: db {dbHost} is down", rendered)
+
+	// ---- WHEN
+	kt_errors.SetDefaultRenderTemplate(nil)
+	rendered, err = fault.Render(nil)
+	// ---- THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "[illegal_state] db {dbHost} is down", rendered)
+}
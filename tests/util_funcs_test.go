@@ -1,11 +1,16 @@
 package kt_error_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/keytiles/lib-errorhandling-golang/v2/pkg/kt_errors"
+	"github.com/keytiles/lib-logging-golang/v2/pkg/kt_logging"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc/codes"
 )
 
@@ -128,3 +133,703 @@ func TestGrpcStatusCodeFromFault(t *testing.T) {
 	}
 
 }
+
+func TestAuthFaultsGrpcStatusCodes(t *testing.T) {
+
+	// ---- GIVEN
+	testCases := []struct {
+		kind           kt_errors.FaultKind
+		errCode        string
+		expectedStatus codes.Code
+	}{
+		{kt_errors.AuthenticationFault, kt_errors.AUTHENTICATION_ERRCODE_MISSING, codes.Unauthenticated},
+		{kt_errors.AuthenticationFault, kt_errors.AUTHENTICATION_ERRCODE_INVALID, codes.Unauthenticated},
+		{kt_errors.AuthenticationFault, kt_errors.AUTHENTICATION_ERRCODE_EXPIRED, codes.Unauthenticated},
+		{kt_errors.AuthenticationFault, kt_errors.AUTHENTICATION_ERRCODE_FAILED, codes.Unauthenticated},
+		{kt_errors.AuthenticationFault, kt_errors.AUTHENTICATION_ERRCODE_NOT_SUPPORTED, codes.Unimplemented},
+		{kt_errors.AuthorizationFault, kt_errors.AUTHORIZATION_NO_PERMISSION, codes.PermissionDenied},
+		{kt_errors.AuthorizationFault, kt_errors.AUTHORIZATION_ERRCODE_FAILED, codes.Internal},
+	}
+
+	for _, testCase := range testCases {
+		// ---- WHEN
+		fault := kt_errors.NewPublicFaultBuilder(testCase.kind).WithErrorCode(testCase.errCode).Build()
+		// ---- THEN
+		assert.Equal(t, testCase.expectedStatus, kt_errors.GetGrpcStatusCodeForFault(fault), "error code '%s'", testCase.errCode)
+	}
+
+	// ---- WHEN
+	// a clear denial wins over the process failure
+	fault := kt_errors.NewPublicFaultBuilder(kt_errors.AuthorizationFault).
+		WithErrorCodes(kt_errors.AUTHORIZATION_ERRCODE_FAILED, kt_errors.AUTHORIZATION_NO_PERMISSION).
+		Build()
+	// ---- THEN
+	assert.Equal(t, codes.PermissionDenied, kt_errors.GetGrpcStatusCodeForFault(fault))
+}
+
+func TestVersionConflictStatusCodes(t *testing.T) {
+
+	// ---- WHEN
+	fault := kt_errors.NewPublicFaultBuilder(kt_errors.ConstraintViolationFault).
+		WithErrorCode(kt_errors.CONSTRAINTVIOLATION_ERRCODE_VERSION_CONFLICT).
+		Build()
+	// ---- THEN
+	assert.Equal(t, 409, kt_errors.GetHttpStatusCodeForFault(fault))
+	assert.Equal(t, codes.Aborted, kt_errors.GetGrpcStatusCodeForFault(fault))
+
+	// ---- WHEN
+	// other constraint codes keep the default
+	fault = kt_errors.NewPublicFaultBuilder(kt_errors.ConstraintViolationFault).
+		WithErrorCode(kt_errors.CONSTRAINTVIOLATION_ERRCODE_PRECONDITION_FAILED).
+		Build()
+	// ---- THEN
+	assert.Equal(t, 412, kt_errors.GetHttpStatusCodeForFault(fault))
+	assert.Equal(t, codes.FailedPrecondition, kt_errors.GetGrpcStatusCodeForFault(fault))
+}
+
+func TestRateLimitedFault(t *testing.T) {
+
+	// ---- WHEN
+	fault := kt_errors.NewPublicFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("too many requests").
+		WithErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_RATE_LIMITED).
+		WithRetryAfter(30 * time.Second).
+		Build()
+
+	// ---- THEN
+	assert.Equal(t, 429, kt_errors.GetHttpStatusCodeForFault(fault))
+	assert.Equal(t, codes.ResourceExhausted, kt_errors.GetGrpcStatusCodeForFault(fault))
+	// retryable by default - and the hint is there
+	assert.True(t, fault.IsRetryable())
+	retryAfter, found := fault.GetRetryAfter()
+	assert.True(t, found)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	// ---- WHEN
+	// explicit decision wins
+	fault = kt_errors.NewPublicFaultBuilder(kt_errors.IllegalStateFault).
+		WithErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_RATE_LIMITED).
+		WithIsRetryable(false).
+		Build()
+	// ---- THEN
+	assert.False(t, fault.IsRetryable())
+	_, found = fault.GetRetryAfter()
+	assert.False(t, found)
+	// non-public - still internal error
+	assert.Equal(t, 500, kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).WithErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_RATE_LIMITED).Build().GetHttpStatusCode())
+
+	// ---- WHEN
+	// rate limiting wins over the other codes - the same way in HTTP and gRPC
+	fault = kt_errors.NewPublicFaultBuilder(kt_errors.IllegalStateFault).
+		WithErrorCodes(kt_errors.ILLEGALSTATE_ERRCODE_RATE_LIMITED, kt_errors.ILLEGALSTATE_ERRCODE_TIMED_OUT).
+		Build()
+	// ---- THEN
+	assert.Equal(t, 429, kt_errors.GetHttpStatusCodeForFault(fault))
+	assert.Equal(t, codes.ResourceExhausted, kt_errors.GetGrpcStatusCodeForFault(fault))
+}
+
+func TestPublicFaultCreation_fromMultipleErrors(t *testing.T) {
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Only nils
+
+	// ---- WHEN
+	converted := kt_errors.NewPublicFaultFromErrors([]error{nil, nil}, "trId", nil)
+	// ---- THEN
+	assert.Nil(t, converted)
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// Mixed retryable / non-retryable errors
+
+	// ---- GIVEN
+	retryableFault := kt_errors.NewPublicFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("first problem").
+		WithIsRetryable(true).
+		WithErrorCodes(kt_errors.ILLEGALSTATE_ERRCODE_TIMED_OUT).
+		Build()
+	plainErr := fmt.Errorf("unsafe error")
+
+	// ---- WHEN
+	converted = kt_errors.NewPublicFaultFromErrors([]error{nil, retryableFault, plainErr}, "trId", nil)
+
+	// ---- THEN
+	assert.NotNil(t, converted)
+	assert.True(t, converted.IsPublic())
+	assert.False(t, converted.IsRetryable())
+	assert.Equal(t, kt_errors.IllegalStateFault, converted.GetKind())
+	assert.Equal(t, "first problem", converted.GetMessage())
+	assert.True(t, converted.HasErrorCode(kt_errors.ILLEGALSTATE_ERRCODE_TIMED_OUT))
+	additionalErrors, found := converted.GetLabel("additionalErrors")
+	assert.True(t, found)
+	assert.Equal(t, []string{"Error occurred during processing, details are logged with transactionId 'trId'"}, additionalErrors)
+	assert.Equal(t, []error{retryableFault, plainErr}, converted.GetCauses())
+	assert.ErrorIs(t, converted, plainErr)
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// All errors are retryable
+
+	// ---- GIVEN
+	otherRetryableFault := kt_errors.NewPublicFaultBuilder(kt_errors.RuntimeFault).
+		WithMessageTemplate("second problem").
+		WithIsRetryable(true).
+		Build()
+
+	// ---- WHEN
+	converted = kt_errors.NewPublicFaultFromErrors([]error{retryableFault, otherRetryableFault}, "trId", nil)
+
+	// ---- THEN
+	assert.True(t, converted.IsRetryable())
+	additionalErrors, _ = converted.GetLabel("additionalErrors")
+	assert.Equal(t, []string{"second problem"}, additionalErrors)
+
+	// ==================
+	// Scenario 4
+	// ==================
+	// The reference the client sees is the logged one
+
+	// ---- GIVEN
+	logs, detach := observeDefaultLogger()
+	defer detach()
+	// real instance ids - so they can be told apart
+	kt_errors.SetInstanceIdGenerator(nil)
+	defer kt_errors.SetInstanceIdGenerator(func() string { return testInstanceId })
+
+	// ---- WHEN
+	converted = kt_errors.NewPublicFaultFromErrors([]error{plainErr, fmt.Errorf("other unsafe error")}, "trId", nil)
+
+	// ---- THEN
+	assert.NotEmpty(t, converted.GetReference())
+	assert.Equal(t, 1, logs.FilterField(zap.String("errorReference", converted.GetReference())).Len())
+	// and so is the instance id - which is the same as the "instanceId" label
+	instanceIdLabel, _ := converted.GetLabel("instanceId")
+	assert.Equal(t, converted.GetInstanceId(), instanceIdLabel)
+	assert.Equal(t, 1, logs.FilterField(zap.String("instanceId", converted.GetInstanceId())).Len())
+}
+
+func TestHttpStatusCodeFromChain(t *testing.T) {
+
+	// ---- GIVEN
+	validationFault := kt_errors.NewPublicFaultBuilder(kt_errors.ValidationFault).
+		WithMessageTemplate("invalid input").
+		WithErrorCodes(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+		Build()
+	wrapper := kt_errors.NewPublicFaultBuilder(kt_errors.RuntimeFault).
+		WithMessageTemplate("processing failed").
+		WithCause(fmt.Errorf("wrapped: %w", validationFault)).
+		Build()
+
+	// ---- WHEN / THEN
+	// default behavior is unchanged
+	assert.Equal(t, 500, wrapper.GetHttpStatusCode())
+	// but walking the chain we find the 400
+	assert.Equal(t, 400, wrapper.GetHttpStatusCodeFromChain())
+	assert.Equal(t, 400, kt_errors.GetHttpStatusCodeFromChainForFault(wrapper))
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// the nested fault is not public - then it does not count
+
+	// ---- GIVEN
+	nonPublicValidationFault := kt_errors.NewFaultBuilder(kt_errors.ValidationFault).Build()
+	wrapper = kt_errors.NewPublicFaultBuilder(kt_errors.RuntimeFault).
+		WithCause(nonPublicValidationFault).
+		Build()
+	// ---- WHEN / THEN
+	assert.Equal(t, 500, wrapper.GetHttpStatusCodeFromChain())
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// the wrapper is not public - always 500
+
+	// ---- GIVEN
+	wrapper = kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).
+		WithCause(validationFault).
+		Build()
+	// ---- WHEN / THEN
+	assert.Equal(t, 500, wrapper.GetHttpStatusCodeFromChain())
+
+	// ==================
+	// Scenario 4
+	// ==================
+	// nil fault
+
+	// ---- WHEN / THEN
+	assert.Equal(t, 200, kt_errors.GetHttpStatusCodeFromChainForFault(nil))
+}
+
+func TestPublicFaultCreation_customConversionMessageTemplate(t *testing.T) {
+
+	// ---- GIVEN
+	kt_errors.SetDefaultConversionMessageTemplate("Hiba történt, részletek a naplóban: {transactionId}", "Hiba történt")
+	defer kt_errors.SetDefaultConversionMessageTemplate("", "")
+	original := fmt.Errorf("unsafe error")
+
+	// ---- WHEN
+	withTxId := kt_errors.NewPublicFaultFromAnyError(original, "trId", nil)
+	withoutTxId := kt_errors.NewPublicFaultFromAnyError(original, "", nil)
+
+	// ---- THEN
+	assert.Equal(t, "Hiba történt, részletek a naplóban: {transactionId}", withTxId.GetMessageTemplate())
+	assert.Equal(t, "Hiba történt, részletek a naplóban: trId", withTxId.GetMessage())
+	assert.Equal(t, "Hiba történt", withoutTxId.GetMessage())
+	assert.Equal(
+		t,
+		map[string]string{"withTxId": "Hiba történt, részletek a naplóban: {transactionId}", "withoutTxId": "Hiba történt"},
+		kt_errors.DumpRegistries()["conversionMessageTemplates"],
+	)
+
+	// ---- WHEN
+	// restoring defaults
+	kt_errors.SetDefaultConversionMessageTemplate("", "")
+	withoutTxId = kt_errors.NewPublicFaultFromAnyError(original, "", nil)
+
+	// ---- THEN
+	assert.Equal(t, "Error occurred during processing, details are logged", withoutTxId.GetMessage())
+
+	// ---- WHEN
+	// template without the transactionId placeholder is rejected - the default is used
+	kt_errors.SetDefaultConversionMessageTemplate("Hiba történt, részletek a naplóban", "Hiba történt")
+	withTxId = kt_errors.NewPublicFaultFromAnyError(original, "trId", nil)
+	withoutTxId = kt_errors.NewPublicFaultFromAnyError(original, "", nil)
+
+	// ---- THEN
+	assert.Equal(t, "Error occurred during processing, details are logged with transactionId 'trId'", withTxId.GetMessage())
+	assert.Equal(t, "Hiba történt", withoutTxId.GetMessage())
+}
+
+func TestSanitize(t *testing.T) {
+
+	// ---- GIVEN
+	original := kt_errors.NewFaultBuilder(kt_errors.ValidationFault).
+		WithMessageTemplate("internal message with {dbHost}").
+		WithMessageTemplateForAudience(kt_errors.MSGAUDIENCE_USER, "user facing message with {userName}").
+		WithMessageTemplateForAudience("operator", "operator message").
+		WithErrorCodes(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+		WithLabel("userName", "john").
+		WithLabel("dbHost", "secret-db.internal").
+		Build()
+
+	// ---- WHEN
+	sanitized := kt_errors.Sanitize(original)
+	converted := kt_errors.NewPublicFaultFromAnyError(original, "", nil)
+
+	// ---- THEN
+	// same outcome as the conversion - apart from the reference and the "instanceId" label which only the conversion with logging adds
+	assert.True(t, sanitized.IsPublic())
+	assert.Equal(t, converted.GetKind(), sanitized.GetKind())
+	assert.Equal(t, converted.GetMessageTemplate(), sanitized.GetMessageTemplate())
+	assert.Equal(t, converted.GetMessageTemplatesByAudience(), sanitized.GetMessageTemplatesByAudience())
+	assert.Equal(t, converted.GetErrorCodes(), sanitized.GetErrorCodes())
+	expectedLabels := sanitized.GetLabels()
+	expectedLabels["instanceId"] = converted.GetInstanceId()
+	assert.Equal(t, expectedLabels, converted.GetLabels())
+	assert.Equal(t, converted.IsRetryable(), sanitized.IsRetryable())
+	assert.Equal(t, original, sanitized.GetCause())
+	assert.Equal(t, map[string]any{"userName": "john"}, sanitized.GetLabels())
+
+	// ---- WHEN
+	// options are supported too
+	sanitized = kt_errors.Sanitize(original, kt_errors.OptionWhitelistedFaultKinds(true, kt_errors.ValidationFault))
+	// ---- THEN
+	assert.Equal(t, kt_errors.ValidationFault, sanitized.GetKind())
+	assert.Equal(t, []string{kt_errors.VALIDATION_ERRCODE_INVALID_VALUE}, sanitized.GetErrorCodes())
+
+	// ---- WHEN / THEN
+	// public and nil faults are returned as they are
+	publicFault := kt_errors.NewPublicFaultBuilder(kt_errors.ValidationFault).Build()
+	assert.Equal(t, publicFault, kt_errors.Sanitize(publicFault))
+	assert.Nil(t, kt_errors.Sanitize(nil))
+}
+
+func TestOptionPreserveMessageForWhitelisted(t *testing.T) {
+
+	// ---- GIVEN
+	validationFault := kt_errors.NewFaultBuilder(kt_errors.ValidationFault).
+		WithMessageTemplate("field {field} is invalid").
+		WithLabel("field", "email").
+		Build()
+	notFoundFault := kt_errors.NewFaultBuilder(kt_errors.ResourceNotFoundFault).
+		WithMessageTemplate("user {userId} not found in db").
+		WithLabel("userId", "u-1").
+		Build()
+	options := []kt_errors.ConversionOption{
+		kt_errors.OptionWhitelistedFaultKinds(false, kt_errors.ValidationFault),
+		kt_errors.OptionPreserveMessageForWhitelisted(),
+	}
+
+	// ---- WHEN
+	converted := kt_errors.Sanitize(validationFault, options...)
+	// ---- THEN
+	// kind was whitelisted - message is kept together with the labels it needs
+	assert.Equal(t, kt_errors.ValidationFault, converted.GetKind())
+	assert.Equal(t, "field {field} is invalid", converted.GetMessageTemplate())
+	assert.Equal(t, "field email is invalid", converted.GetMessage())
+
+	// ---- WHEN
+	converted = kt_errors.Sanitize(notFoundFault, options...)
+	// ---- THEN
+	// kind was not whitelisted - message is hidden
+	assert.Equal(t, kt_errors.RuntimeFault, converted.GetKind())
+	assert.NotEqual(t, notFoundFault.GetMessageTemplate(), converted.GetMessageTemplate())
+	assert.NotContains(t, converted.GetMessage(), "u-1")
+
+	// ---- WHEN
+	// without the option the message of a whitelisted kind is hidden too
+	converted = kt_errors.Sanitize(validationFault, kt_errors.OptionWhitelistedFaultKinds(false, kt_errors.ValidationFault))
+	// ---- THEN
+	assert.Equal(t, kt_errors.ValidationFault, converted.GetKind())
+	assert.NotEqual(t, validationFault.GetMessageTemplate(), converted.GetMessageTemplate())
+
+	// ---- WHEN
+	// the original has no message - the generic message is used
+	withoutMessage := kt_errors.NewFaultBuilder(kt_errors.ValidationFault).Build()
+	converted = kt_errors.NewPublicFaultFromAnyError(withoutMessage, "trId", nil, options...)
+	// ---- THEN
+	assert.Equal(t, kt_errors.ValidationFault, converted.GetKind())
+	assert.Equal(t, "Error occurred during processing, details are logged with transactionId 'trId'", converted.GetMessage())
+}
+
+func TestOptionAttachFingerprint(t *testing.T) {
+
+	// ---- GIVEN
+	original := kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("db {dbHost} is down").
+		WithErrorCodes(kt_errors.ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE).
+		WithLabel("dbHost", "secret-db.internal").
+		Build()
+	logs, detach := observeDefaultLogger()
+	defer detach()
+
+	// ---- WHEN
+	converted := kt_errors.NewPublicFaultFromAnyError(original, "", nil, kt_errors.OptionAttachFingerprint())
+
+	// ---- THEN
+	// the short code is on the public Fault and in the log as well - with the same name
+	assert.Regexp(t, "^[0-9A-F]{8}$", original.GetFingerprint())
+	reference, found := converted.GetLabel("fingerprint")
+	assert.True(t, found)
+	assert.Equal(t, original.GetFingerprint(), reference)
+	assert.Equal(t, 1, logs.FilterField(zap.String("fingerprint", original.GetFingerprint())).Len())
+
+	// ---- WHEN / THEN
+	// the fingerprint is stable - it does not depend on label values
+	sameError := kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("db {dbHost} is down").
+		WithErrorCodes(kt_errors.ILLEGALSTATE_ERRCODE_DEPENDENCY_UNAVAILABLE).
+		WithLabel("dbHost", "other-db.internal").
+		Build()
+	assert.Equal(t, original.GetFingerprint(), sameError.GetFingerprint())
+	assert.NotEqual(t, original.GetFingerprint(), kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).WithMessageTemplate("other").Build().GetFingerprint())
+
+	// ---- WHEN / THEN
+	// non-Fault errors get one too
+	converted = kt_errors.NewPublicFaultFromAnyError(errors.New("boom"), "", nil, kt_errors.OptionAttachFingerprint())
+	reference, found = converted.GetLabel("fingerprint")
+	assert.True(t, found)
+	assert.Regexp(t, "^[0-9A-F]{8}$", reference)
+	// and it does not depend on the ids in the error text
+	converted = kt_errors.NewPublicFaultFromAnyError(errors.New("order 1234 of user 550e8400-e29b-41d4-a716-446655440000 failed"), "", nil,
+		kt_errors.OptionAttachFingerprint())
+	otherConverted := kt_errors.NewPublicFaultFromAnyError(errors.New("order 98 of user 6ba7b810-9dad-11d1-80b4-00c04fd430c8 failed"), "", nil,
+		kt_errors.OptionAttachFingerprint())
+	reference, _ = converted.GetLabel("fingerprint")
+	otherReference, _ := otherConverted.GetLabel("fingerprint")
+	assert.Equal(t, reference, otherReference)
+
+	// ---- WHEN / THEN
+	// without the option there is no such label
+	converted = kt_errors.NewPublicFaultFromAnyError(original, "", nil)
+	_, found = converted.GetLabel("fingerprint")
+	assert.False(t, found)
+}
+
+func TestOptionLogConfig(t *testing.T) {
+
+	// ---- GIVEN
+	original := kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).WithMessageTemplate("expected failure").Build()
+	logs, detach := observeDefaultLogger()
+	defer detach()
+
+	// ---- WHEN
+	converted := kt_errors.NewPublicFaultFromAnyError(original, "", nil, kt_errors.OptionLogConfig(kt_logging.WarningLevel, true))
+	// ---- THEN
+	// conversion still happens - but nothing is logged
+	assert.True(t, converted.IsPublic())
+	assert.Equal(t, original, converted.GetCause())
+	assert.Equal(t, 0, logs.Len())
+
+	// ---- WHEN
+	kt_errors.NewPublicFaultFromAnyError(original, "", nil, kt_errors.OptionLogConfig(kt_logging.ErrorLevel, false))
+	// ---- THEN
+	entries := logs.TakeAll()
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+
+	// ---- WHEN
+	// by default it is a warning
+	kt_errors.NewPublicFaultFromAnyError(errors.New("boom"), "", nil)
+	// ---- THEN
+	entries = logs.TakeAll()
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+
+	// ---- WHEN
+	// unknown level - falls back to warning instead of swallowing the log
+	kt_errors.NewPublicFaultFromAnyError(errors.New("boom"), "", nil, kt_errors.OptionLogConfig(kt_logging.LogLevel(42), false))
+	// ---- THEN
+	entries = logs.TakeAll()
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+
+	// ---- GIVEN
+	withLabels := kt_errors.NewFaultBuilder(kt_errors.ValidationFault).
+		WithMessageTemplate("internal message").
+		WithMessageTemplateForAudience(kt_errors.MSGAUDIENCE_USER, "{field} is invalid").
+		WithLabel("field", "email").
+		Build()
+	// ---- WHEN
+	// the notices about the labels follow the configured level too
+	kt_errors.NewPublicFaultFromAnyError(withLabels, "", nil, kt_errors.OptionLogConfig(kt_logging.ErrorLevel, false))
+	// ---- THEN
+	entries = logs.TakeAll()
+	assert.Equal(t, 2, len(entries))
+	for _, entry := range entries {
+		assert.Equal(t, zapcore.ErrorLevel, entry.Level)
+	}
+
+	// ---- WHEN
+	kt_errors.NewPublicFaultFromAnyError(withLabels, "", nil, kt_errors.OptionLogConfig(kt_logging.ErrorLevel, true))
+	// ---- THEN
+	assert.Equal(t, 0, logs.Len())
+}
+
+// error type which can be linked into a cycle
+type loopingError struct {
+	next error
+}
+
+func (e *loopingError) Error() string { return "looping" }
+func (e *loopingError) Unwrap() error { return e.next }
+
+func TestWalkErrorChain(t *testing.T) {
+
+	// ---- GIVEN
+	// a mixed chain: Fault -> wrapped stdlib error -> Fault -> stdlib error
+	rootErr := errors.New("root")
+	innerFault := kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).WithMessageTemplate("inner").WithCause(rootErr).Build()
+	wrapped := fmt.Errorf("wrapped: %w", innerFault)
+	outerFault := kt_errors.NewFaultBuilder(kt_errors.RuntimeFault).WithMessageTemplate("outer").WithCause(wrapped).Build()
+
+	// ---- WHEN
+	var collected []error
+	outerFault.WalkCauses(func(err error) bool {
+		collected = append(collected, err)
+		return true
+	})
+	// ---- THEN
+	assert.Equal(t, []error{outerFault, wrapped, innerFault, rootErr}, collected)
+
+	// ---- WHEN
+	// early stop - find the first non-public Fault below the top
+	collected = nil
+	var found kt_errors.Fault
+	outerFault.WalkCauses(func(err error) bool {
+		collected = append(collected, err)
+		if isFault, fault := kt_errors.IsFault(err); isFault && fault != outerFault {
+			found = fault
+			return false
+		}
+		return true
+	})
+	// ---- THEN
+	assert.Equal(t, innerFault, found)
+	assert.Len(t, collected, 3)
+
+	// ---- WHEN
+	// free function works on any error
+	collected = nil
+	kt_errors.WalkErrorChain(wrapped, func(err error) bool {
+		collected = append(collected, err)
+		return true
+	})
+	// ---- THEN
+	assert.Equal(t, []error{wrapped, innerFault, rootErr}, collected)
+
+	// ---- GIVEN
+	loop1 := &loopingError{}
+	loop2 := &loopingError{next: loop1}
+	loop1.next = loop2
+	// ---- WHEN
+	collected = nil
+	kt_errors.WalkErrorChain(loop1, func(err error) bool {
+		collected = append(collected, err)
+		return true
+	})
+	// ---- THEN
+	// cycle is detected
+	assert.Equal(t, []error{loop1, loop2}, collected)
+
+	// ---- WHEN
+	// nil error - callback is never invoked
+	invoked := false
+	kt_errors.WalkErrorChain(nil, func(err error) bool {
+		invoked = true
+		return true
+	})
+	// ---- THEN
+	assert.False(t, invoked)
+}
+
+func TestWalkErrorChainMultiCause(t *testing.T) {
+
+	// ---- GIVEN
+	// Fault with two causes: a Fault and an `errors.Join()` of a plain error and a retryable Fault
+	firstCause := kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).WithMessageTemplate("first").Build()
+	plainErr := errors.New("plain")
+	retryableFault := kt_errors.NewPublicFaultBuilder(kt_errors.IllegalStateFault).
+		WithMessageTemplate("retryable").
+		WithIsRetryable(true).
+		Build()
+	joined := errors.Join(plainErr, retryableFault)
+	fault := kt_errors.NewPublicFaultBuilder(kt_errors.RuntimeFault).WithCauses(firstCause, joined).Build()
+
+	// ---- WHEN
+	var collected []error
+	kt_errors.WalkErrorChain(fault, func(err error) bool {
+		collected = append(collected, err)
+		return true
+	})
+	// ---- THEN
+	// every branch is walked - depth first, in order
+	assert.Equal(t, []error{fault, firstCause, joined, plainErr, retryableFault}, collected)
+
+	// ---- WHEN / THEN
+	// and so the chain based functions see all the branches too
+	assert.True(t, fault.IsRetryableInChain())
+	assert.Equal(t, []kt_errors.Fault{firstCause, retryableFault}, fault.GetFaultCauseChain())
+	depth, faultCount, hasNonPublic := fault.ChainSummary()
+	assert.Equal(t, 3, depth)
+	assert.Equal(t, 3, faultCount)
+	assert.True(t, hasNonPublic)
+}
+
+func TestKindCategory(t *testing.T) {
+
+	// ---- GIVEN
+	expectedCategories := map[kt_errors.FaultKind]string{
+		kt_errors.RuntimeFault:             kt_errors.KINDCATEGORY_SERVER,
+		kt_errors.IllegalStateFault:        kt_errors.KINDCATEGORY_SERVER,
+		kt_errors.NotImplementedFault:      kt_errors.KINDCATEGORY_SERVER,
+		kt_errors.ValidationFault:          kt_errors.KINDCATEGORY_CLIENT,
+		kt_errors.ConstraintViolationFault: kt_errors.KINDCATEGORY_CLIENT,
+		kt_errors.ResourceNotFoundFault:    kt_errors.KINDCATEGORY_CLIENT,
+		kt_errors.AuthenticationFault:      kt_errors.KINDCATEGORY_CLIENT,
+		kt_errors.AuthorizationFault:       kt_errors.KINDCATEGORY_CLIENT,
+	}
+	assert.Len(t, expectedCategories, len(allFaultKinds))
+
+	for _, kind := range allFaultKinds {
+		// ---- WHEN
+		category := kt_errors.KindCategory(kind)
+		fault := kt_errors.NewFaultBuilder(kind).Build()
+
+		// ---- THEN
+		assert.Equal(t, expectedCategories[kind], category, "kind: %s", kind)
+		assert.Equal(t, category == kt_errors.KINDCATEGORY_CLIENT, fault.IsClientError(), "kind: %s", kind)
+		assert.Equal(t, category == kt_errors.KINDCATEGORY_SERVER, fault.IsServerError(), "kind: %s", kind)
+	}
+
+	// custom kinds are server side
+	assert.Equal(t, kt_errors.KINDCATEGORY_SERVER, kt_errors.KindCategory("my_custom_kind"))
+}
+
+func TestDiffFaults(t *testing.T) {
+
+	// ---- GIVEN
+	newBuilder := func() *kt_errors.FaultBuilder {
+		return kt_errors.NewFaultBuilder(kt_errors.ValidationFault).
+			WithMessageTemplate("field {field} is invalid").
+			WithErrorCodes(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+			WithLabel("field", "name")
+	}
+
+	// ==================
+	// Scenario 1
+	// ==================
+	// Equal Faults - no diff
+
+	// ---- WHEN / THEN
+	assert.Equal(t, "", kt_errors.DiffFaults(newBuilder().Build(), newBuilder().Build()))
+
+	// ==================
+	// Scenario 2
+	// ==================
+	// A changed error code and a changed label
+
+	// ---- GIVEN
+	golden := newBuilder().WithLabel("limit", 32).Build()
+	actual := newBuilder().
+		WithoutErrorCodes(kt_errors.VALIDATION_ERRCODE_INVALID_VALUE).
+		WithErrorCode(kt_errors.VALIDATION_ERRCODE_WRONG_FORMAT).
+		WithLabel("field", "email").
+		Build()
+
+	// ---- WHEN
+	diff := kt_errors.DiffFaults(golden, actual)
+
+	// ---- THEN
+	assert.Equal(
+		t,
+		`error code 'invalid_value': only in a
+error code 'wrong_format': only in b
+label 'field': "name" != "email"
+label 'limit': only in a (32)`,
+		diff,
+	)
+
+	// ---- WHEN
+	diff = kt_errors.DiffFaults(golden, golden.WithKindOverride(kt_errors.IllegalStateFault))
+	// ---- THEN
+	assert.Equal(t, `kind: "validation" != "illegal_state"`, diff)
+
+	// ==================
+	// Scenario 3
+	// ==================
+	// Nil operands
+
+	// ---- WHEN / THEN
+	assert.Equal(t, "", kt_errors.DiffFaults(nil, nil))
+	assert.Equal(t, "", kt_errors.DiffFaults(nil, kt_errors.NoFault))
+	assert.Equal(t, "a: no Fault, b: "+golden.String(), kt_errors.DiffFaults(nil, golden))
+	assert.Equal(t, "a: "+golden.String()+", b: no Fault", kt_errors.DiffFaults(golden, nil))
+}
+
+func TestBoundedLabels(t *testing.T) {
+
+	// ---- GIVEN
+	fault := kt_errors.NewFaultBuilder(kt_errors.IllegalStateFault).
+		WithLabel("tenantId", "t-1").
+		WithLabel("attempt", 3).
+		WithLabel("degraded", true).
+		WithLabel("requestId", "r-123456").
+		Build()
+
+	// ---- WHEN
+	labels := kt_errors.BoundedLabels(fault, "tenantId", "attempt", "degraded", "missing")
+	// ---- THEN
+	// only the allowed ones - stringified, missing labels are skipped
+	assert.Equal(t, map[string]string{"tenantId": "t-1", "attempt": "3", "degraded": "true"}, labels)
+
+	// ---- WHEN / THEN
+	assert.Equal(t, map[string]string{}, kt_errors.BoundedLabels(fault))
+	assert.Equal(t, map[string]string{}, kt_errors.BoundedLabels(nil, "tenantId"))
+	assert.Equal(t, map[string]string{}, kt_errors.BoundedLabels(kt_errors.NoFault, "tenantId"))
+}